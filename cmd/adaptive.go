@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/game"
+	"github.com/nemaniabhiram/zentype.cli/internal/ui"
+)
+
+// CalibrationDuration is the length in seconds of the --adaptive calibration test
+const CalibrationDuration = 15
+
+// calibrate runs the short --adaptive calibration test and returns the
+// measured baseline with a word generator for the difficulty tier that matches
+// it, sampled with dist. The generator is nil if the calibration was quit.
+func calibrate(language, dist string) (game.WordGenerator, ui.Adaptive, error) {
+	results, err := runSeries([]seriesLeg{{
+		Label:    fmt.Sprintf("%ds calibration", CalibrationDuration),
+		Duration: CalibrationDuration,
+		Language: language,
+		Options:  ui.Options{NoSubmit: true},
+	}})
+	if err != nil || results == nil {
+		return nil, ui.Adaptive{}, err
+	}
+
+	adaptive := ui.Adaptive{Baseline: results[0].Stats.WPM}
+	adaptive.Difficulty = game.DifficultyForWPM(adaptive.Baseline)
+	generator, err := game.TieredWords(language, adaptive.Difficulty, dist)
+	if err != nil {
+		return nil, ui.Adaptive{}, err
+	}
+	fmt.Println(adaptive)
+	return generator, adaptive, nil
+}
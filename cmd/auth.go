@@ -7,6 +7,7 @@ import (
 
 	"github.com/nemaniabhiram/zentype.cli/internal/api"
 	"github.com/nemaniabhiram/zentype.cli/internal/auth"
+	"github.com/nemaniabhiram/zentype.cli/internal/ui"
 
 	"github.com/spf13/cobra"
 )
@@ -22,9 +23,14 @@ This command will:
 2. Guide you through the authentication process
 3. Save your authentication token locally
 
+On a headless machine or over SSH, use --url to print the sign-in URL
+without trying to open a browser; open it on any other device and paste the
+token back here.
+
 Your GitHub account will be used as your leaderboard identity.
 Only 60-second tests with 85%+ accuracy will be submitted to the leaderboard.`,
 	Example: `  zentype auth
+  zentype auth --url
   zentype auth --logout
   zentype auth --status`,
 	RunE: runAuth,
@@ -33,11 +39,13 @@ Only 60-second tests with 85%+ accuracy will be submitted to the leaderboard.`,
 var (
 	authLogout bool
 	authStatus bool
+	authURLOnly bool // Print the sign-in URL instead of opening a browser
 )
 
 func init() {
 	authCmd.Flags().BoolVar(&authLogout, "logout", false, "Logout and clear saved authentication")
 	authCmd.Flags().BoolVar(&authStatus, "status", false, "Show current authentication status")
+	authCmd.Flags().BoolVar(&authURLOnly, "url", false, "Print the GitHub sign-in URL to open elsewhere instead of opening a browser")
 	rootCmd.AddCommand(authCmd)
 }
 
@@ -67,7 +75,7 @@ func runAuth(cmd *cobra.Command, args []string) error {
 	if authStatus {
 		if authManager.IsAuthenticated() {
 			user := authManager.GetUser()
-			fmt.Printf("✓ Authenticated as: %s (@%s)\n", user.Username, user.GitHubLogin)
+			fmt.Printf("✓ Authenticated as: %s (@%s)\n", ui.TruncateName(user.Username, ui.MaxNameWidth), user.GitHubLogin)
 			fmt.Printf("  GitHub ID: %d\n", user.GitHubID)
 			fmt.Printf("  Authenticated: %s\n", user.CreatedAt.Format("Jan 2, 2006 15:04"))
 			
@@ -87,7 +95,7 @@ func runAuth(cmd *cobra.Command, args []string) error {
 	// Check if already authenticated
 	if authManager.IsAuthenticated() {
 		user := authManager.GetUser()
-		fmt.Printf("✓ Already authenticated as %s (@%s)\n", user.Username, user.GitHubLogin)
+		fmt.Printf("✓ Already authenticated as %s (@%s)\n", ui.TruncateName(user.Username, ui.MaxNameWidth), user.GitHubLogin)
 		fmt.Println("  Use 'zentype auth --logout' to logout")
 		fmt.Println("  Use 'zentype auth --status' for more details")
 		return nil
@@ -113,14 +121,19 @@ func runAuth(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get authentication URL: %w", err)
 	}
 
-	fmt.Println("📱 Opening GitHub OAuth in your browser...")
-	fmt.Println("If the browser doesn't open automatically, copy this URL:")
-	fmt.Printf("\n%s\n\n", authData.AuthURL)
-
-	// Try to open browser
-	if err := openBrowser(authData.AuthURL); err != nil {
-		fmt.Printf("⚠ Could not open browser automatically: %v\n", err)
-		fmt.Println("Please copy and paste the full URL above into your browser")
+	if authURLOnly {
+		fmt.Println("📱 Open this URL in a browser on any device:")
+		fmt.Printf("\n%s\n\n", authData.AuthURL)
+	} else {
+		fmt.Println("📱 Opening GitHub OAuth in your browser...")
+		fmt.Println("If the browser doesn't open automatically, copy this URL:")
+		fmt.Printf("\n%s\n\n", authData.AuthURL)
+
+		// Try to open browser
+		if err := openBrowser(authData.AuthURL); err != nil {
+			fmt.Printf("⚠ Could not open browser automatically: %v\n", err)
+			fmt.Println("Please copy and paste the full URL above into your browser")
+		}
 	}
 
 	fmt.Println("👀 Complete the authentication in your browser")
@@ -144,7 +157,7 @@ func runAuth(cmd *cobra.Command, args []string) error {
 	user := authManager.GetUser()
 	fmt.Println()
 	fmt.Printf("✅ Successfully authenticated!\n")
-	fmt.Printf("   Welcome, %s (@%s)\n", user.Username, user.GitHubLogin)
+	fmt.Printf("   Welcome, %s (@%s)\n", ui.TruncateName(user.Username, ui.MaxNameWidth), user.GitHubLogin)
 	fmt.Println()
 	fmt.Println("🎯 You can now compete on the global leaderboard!")
 	fmt.Println("   Run 'zentype start -t 60' to play a ranked game")
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/api"
+	"github.com/nemaniabhiram/zentype.cli/internal/game"
+	"github.com/nemaniabhiram/zentype.cli/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+)
+
+var dailyBoard bool // Show today's daily board instead of playing
+
+// dailyCmd represents the daily challenge command
+var dailyCmd = &cobra.Command{
+	Use:   "daily",
+	Short: "Play today's daily challenge",
+	Long: `Play the daily challenge: a 60-second test where everyone types the same
+words, chosen from the current UTC date. Restarting replays the same words.
+
+Qualifying scores are submitted to that day's daily board, separate from
+the global leaderboard.`,
+	Example: `  zt daily
+  zt daily --board`,
+	RunE: runDaily,
+}
+
+func init() {
+	dailyCmd.Flags().BoolVar(&dailyBoard, "board", false, "Show today's daily challenge board and exit")
+	rootCmd.AddCommand(dailyCmd)
+}
+
+func runDaily(cmd *cobra.Command, args []string) error {
+	today := time.Now().UTC()
+
+	if dailyBoard {
+		board, err := api.NewClient().GetDailyLeaderboard(today.Format("2006-01-02"))
+		if err != nil {
+			return err
+		}
+		fmt.Print(renderDailyBoard(board))
+		return nil
+	}
+
+	if err := requireTerminal(); err != nil {
+		return err
+	}
+
+	model, err := ui.NewModelWithOptions(60, "english", ui.Options{
+		Seed:          game.DailySeed(today),
+		ChallengeDate: today.Format("2006-01-02"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create daily challenge: %w", err)
+	}
+
+	p := tea.NewProgram(model)
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("error running daily challenge: %w", err)
+	}
+
+	return nil
+}
+
+// renderDailyBoard formats the daily challenge board as a plain table
+func renderDailyBoard(board *api.DailyLeaderboardResponse) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.CurrentTheme().Accent)
+	mutedStyle := lipgloss.NewStyle().Foreground(ui.CurrentTheme().Muted)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Daily challenge • %s", board.Date)))
+	b.WriteString("\n\n")
+
+	if len(board.Entries) == 0 {
+		b.WriteString(mutedStyle.Render("No qualifying scores yet today — be the first with 'zt daily'"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	for _, entry := range board.Entries {
+		// Pad by display width, since %-20s counts wide characters as one cell
+		name := runewidth.FillRight(ui.TruncateName(entry.Username, 20), 20)
+		b.WriteString(fmt.Sprintf("%4s  %s %6.0f  %6.1f%%\n",
+			fmt.Sprintf("#%d", entry.Rank), name, entry.WPM, entry.Accuracy))
+	}
+	return b.String()
+}
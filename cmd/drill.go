@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/game"
+	"github.com/nemaniabhiram/zentype.cli/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+var (
+	drillChars    string // Characters that drill words are built from
+	drillDuration int    // Duration of the drill in seconds
+)
+
+// drillCmd represents the drill command for targeted key practice
+var drillCmd = &cobra.Command{
+	Use:   "drill",
+	Short: "Practice a specific set of characters",
+	Long: `Run a typing test made of pseudo-words built only from the given characters.
+Useful for drilling the number row, symbols or a few weak keys.
+
+Drill results are never submitted to the leaderboard.`,
+	Example: `  zt drill --chars "asdf jkl;"
+  zt drill --chars 1234567890 --time 30`,
+	RunE: runDrill,
+}
+
+func init() {
+	drillCmd.Flags().StringVarP(&drillChars, "chars", "c", "", "Characters to practice (required)")
+	drillCmd.Flags().IntVarP(&drillDuration, "time", "t", 60, "Test duration in seconds (10-300)")
+	drillCmd.MarkFlagRequired("chars")
+	rootCmd.AddCommand(drillCmd)
+}
+
+func runDrill(cmd *cobra.Command, args []string) error {
+	if drillDuration < 10 || drillDuration > 300 {
+		return fmt.Errorf("duration must be between 10 and 300 seconds")
+	}
+
+	// Only printable ASCII can be typed during a test
+	usable := 0
+	for _, r := range drillChars {
+		if r == ' ' {
+			continue
+		}
+		if r < 33 || r > 126 {
+			return fmt.Errorf("unsupported character %q: only printable ASCII can be drilled", r)
+		}
+		usable++
+	}
+	if usable == 0 {
+		return fmt.Errorf("--chars must contain at least one non-space character")
+	}
+
+	if err := requireTerminal(); err != nil {
+		return err
+	}
+
+	model, err := ui.NewModelWithOptions(drillDuration, "drill", ui.Options{
+		NoSubmit:  true,
+		Generator: game.DrillWords(drillChars),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create drill: %w", err)
+	}
+
+	p := tea.NewProgram(model)
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("error running drill: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+// feedCmd represents the activity feed command
+var feedCmd = &cobra.Command{
+	Use:   "feed",
+	Short: "Watch recent qualifying scores from all players",
+	Long: `Show a live feed of the most recent qualifying 60-second scores
+across all players. The feed refreshes automatically.`,
+	Example: `  zt feed`,
+	RunE:    runFeed,
+}
+
+func init() {
+	rootCmd.AddCommand(feedCmd)
+}
+
+func runFeed(cmd *cobra.Command, args []string) error {
+	p := tea.NewProgram(ui.NewFeedModel())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("error running feed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/config"
+	"github.com/nemaniabhiram/zentype.cli/internal/game"
+	"github.com/nemaniabhiram/zentype.cli/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// GauntletDurations are the legs of a gauntlet, in the order they are played
+var GauntletDurations = []int{15, 30, 60}
+
+// seriesLeg is one test in a series of tests run back to back
+type seriesLeg struct {
+	Label    string
+	Duration int
+	Language string
+	Options  ui.Options
+}
+
+// seriesResult is the result of one finished leg
+type seriesResult struct {
+	Label string
+	Stats game.TypingStats
+}
+
+// gauntletCmd runs tests of increasing length back to back
+var gauntletCmd = &cobra.Command{
+	Use:   "gauntlet",
+	Short: "Run 15, 30 and 60-second tests back to back and compare them",
+	Long: `Run a 15-second, a 30-second and a 60-second test back to back, then show
+how your WPM and accuracy held up as the tests got longer.
+
+Each test starts when you press Enter, so you can rest between them. Quitting
+a test with Esc ends the gauntlet. Gauntlet results are never submitted to
+the leaderboard.`,
+	RunE: runGauntlet,
+}
+
+func init() {
+	rootCmd.AddCommand(gauntletCmd)
+}
+
+func runGauntlet(cmd *cobra.Command, args []string) error {
+	if err := requireTerminal(); err != nil {
+		return err
+	}
+
+	language := "english"
+	if cfg, err := config.Load(); err == nil && cfg.Language != "" {
+		language = cfg.Language
+	}
+
+	var legs []seriesLeg
+	for _, duration := range GauntletDurations {
+		legs = append(legs, seriesLeg{
+			Label:    fmt.Sprintf("%ds", duration),
+			Duration: duration,
+			Language: language,
+			Options:  ui.Options{NoSubmit: true},
+		})
+	}
+
+	results, err := runSeries(legs)
+	if err != nil || results == nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Print(renderSeries("Gauntlet results", results))
+	return nil
+}
+
+// runSeries runs each leg in turn, waiting for Enter before each one, and
+// returns their results. It returns nil results if a leg was quit early.
+func runSeries(legs []seriesLeg) ([]seriesResult, error) {
+	reader := bufio.NewReader(os.Stdin)
+	var results []seriesResult
+	for i, leg := range legs {
+		fmt.Printf("Test %d of %d: %s. Press Enter to begin...", i+1, len(legs), leg.Label)
+		reader.ReadString('\n')
+
+		// Legs skip the results screen; the series shows them together at the end
+		leg.Options.Quiet = true
+		model, err := ui.NewModelWithOptions(leg.Duration, leg.Language, leg.Options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create typing test: %w", err)
+		}
+		finalModel, err := tea.NewProgram(model).Run()
+		if err != nil {
+			return nil, fmt.Errorf("error running typing test: %w", err)
+		}
+
+		final, ok := asUIModel(finalModel)
+		if !ok {
+			return nil, nil
+		}
+		stats, finished := final.FinalStats()
+		if !finished {
+			fmt.Println("Stopped early")
+			return nil, nil
+		}
+		fmt.Println(formatQuietStats(stats, leg.Language))
+		results = append(results, seriesResult{Label: leg.Label, Stats: stats})
+	}
+	return results, nil
+}
+
+// renderSeries compares the results of a finished series, showing each
+// leg's WPM change from the first
+func renderSeries(title string, results []seriesResult) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.CurrentTheme().Accent)
+	mutedStyle := lipgloss.NewStyle().Foreground(ui.CurrentTheme().Muted)
+
+	width := len("average")
+	for _, result := range results {
+		width = max(width, len(result.Label))
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n\n")
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("%-*s  %6s  %7s  %9s", width, "test", "wpm", "acc", "vs first")))
+	b.WriteString("\n")
+
+	var totalWPM, totalAccuracy float64
+	for i, result := range results {
+		change := mutedStyle.Render(fmt.Sprintf("%9s", "—"))
+		if i > 0 {
+			change = fmt.Sprintf("%+9.0f", result.Stats.WPM-results[0].Stats.WPM)
+		}
+		b.WriteString(fmt.Sprintf("%-*s  %6.0f  %6.1f%%  %s\n",
+			width, result.Label, result.Stats.WPM, result.Stats.Accuracy, change))
+		totalWPM += result.Stats.WPM
+		totalAccuracy += result.Stats.Accuracy
+	}
+
+	b.WriteString(mutedStyle.Render(strings.Repeat("─", width+30)))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%-*s  %6.0f  %6.1f%%\n", width, "average",
+		totalWPM/float64(len(results)), totalAccuracy/float64(len(results))))
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/config"
+	"github.com/nemaniabhiram/zentype.cli/internal/history"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	goalWPM      float64 // Target WPM
+	goalAccuracy float64 // Target accuracy percentage
+	goalClear    bool    // Remove all goals
+)
+
+// goalCmd represents the goal command
+var goalCmd = &cobra.Command{
+	Use:   "goal",
+	Short: "Set personal WPM and accuracy goals",
+	Long: `Set personal goals that are tracked on the results screen after every test.
+Without flags, shows your current goals and your best results so far.`,
+	Example: `  zt goal --wpm 100 --accuracy 98
+  zt goal
+  zt goal --clear`,
+	RunE: runGoal,
+}
+
+func init() {
+	goalCmd.Flags().Float64Var(&goalWPM, "wpm", 0, "Target WPM (0 removes the goal)")
+	goalCmd.Flags().Float64Var(&goalAccuracy, "accuracy", 0, "Target accuracy in percent (0 removes the goal)")
+	goalCmd.Flags().BoolVar(&goalClear, "clear", false, "Remove all goals")
+	rootCmd.AddCommand(goalCmd)
+}
+
+func runGoal(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	if goalClear {
+		cfg.GoalWPM, cfg.GoalAccuracy = 0, 0
+		changed = true
+	}
+	if cmd.Flags().Changed("wpm") {
+		if goalWPM < 0 || goalWPM > 300 {
+			return fmt.Errorf("WPM goal must be between 0 and 300")
+		}
+		cfg.GoalWPM = goalWPM
+		changed = true
+	}
+	if cmd.Flags().Changed("accuracy") {
+		if goalAccuracy < 0 || goalAccuracy > 100 {
+			return fmt.Errorf("accuracy goal must be between 0 and 100")
+		}
+		cfg.GoalAccuracy = goalAccuracy
+		changed = true
+	}
+
+	if changed {
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save goals: %w", err)
+		}
+	}
+
+	if !cfg.HasGoals() {
+		fmt.Println("No goals set. Set one with 'zt goal --wpm 100 --accuracy 98'")
+		return nil
+	}
+
+	// Compare against the best results in local history
+	var bestWPM, bestAccuracy float64
+	if store, err := history.NewStore(); err == nil {
+		entries, _ := store.Load()
+		for _, entry := range entries {
+			if entry.WPM > bestWPM {
+				bestWPM = entry.WPM
+			}
+			if entry.Accuracy > bestAccuracy {
+				bestAccuracy = entry.Accuracy
+			}
+		}
+	}
+
+	if cfg.GoalWPM > 0 {
+		fmt.Printf("WPM goal:      %.0f (best so far %.0f)\n", cfg.GoalWPM, bestWPM)
+	}
+	if cfg.GoalAccuracy > 0 {
+		fmt.Printf("Accuracy goal: %.0f%% (best so far %.1f%%)\n", cfg.GoalAccuracy, bestAccuracy)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/history"
+	"github.com/nemaniabhiram/zentype.cli/internal/ui"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyLimit  int    // Number of recent entries to show
+	historySeeds  bool   // Only show runs that can be replayed, with their seed
+	historyImport string // Merge results from this export file into the local history
+	historyExport string // Write the local history to this file ("-" for stdout)
+	historyVerify bool   // Check the history file for bad entries
+	historyRepair bool   // Remove the bad entries --verify finds
+)
+
+// historyCmd represents the local history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show your recent test results",
+	Long: `Show recent test results from your local history (~/.zentype/history.json).
+
+With --seeds only replayable runs are listed, together with the seed that
+generated their words. Re-run one with 'zt --seed <seed>'.
+
+Move your history between machines with --export and --import. Imports are
+merged by timestamp and runs that are already present are skipped, so
+importing the same file twice is harmless.
+
+--verify checks the history file for entries that can't be read, impossible
+values (negative WPM, accuracy above 100%) and duplicated runs. Add --repair
+to remove them and put the rest back in order; the original file is kept as
+history.json.bak.`,
+	Example: `  zt history
+  zt history --seeds
+  zt history -n 50
+  zt history --export zentype-history.json
+  zt history --import zentype-history.json
+  zt history --verify --repair`,
+	RunE: runHistory,
+}
+
+func init() {
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "Number of recent results to show")
+	historyCmd.Flags().BoolVar(&historySeeds, "seeds", false, "List seeds of recent runs so they can be replayed with --seed")
+	historyCmd.Flags().StringVar(&historyImport, "import", "", "Merge results from an exported history file")
+	historyCmd.Flags().StringVar(&historyExport, "export", "", "Export your history to a file ('-' for stdout)")
+	historyCmd.Flags().BoolVar(&historyVerify, "verify", false, "Check the history file for unreadable, impossible and duplicate entries")
+	historyCmd.Flags().BoolVar(&historyRepair, "repair", false, "With --verify, remove the problems found (the original is kept as history.json.bak)")
+	historyCmd.MarkFlagsMutuallyExclusive("import", "export", "verify")
+	rootCmd.AddCommand(historyCmd)
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	store, err := history.NewStore()
+	if err != nil {
+		return err
+	}
+
+	if historyExport != "" {
+		return exportHistory(store, historyExport)
+	}
+	if historyImport != "" {
+		return importHistory(store, historyImport)
+	}
+	if historyRepair && !historyVerify {
+		return fmt.Errorf("--repair can only be used with --verify")
+	}
+	if historyVerify {
+		return verifyHistory(store, historyRepair)
+	}
+
+	entries, err := store.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load history: %w", err)
+	}
+
+	if historySeeds {
+		var seeded []history.Entry
+		for _, entry := range entries {
+			if entry.Seed != 0 {
+				seeded = append(seeded, entry)
+			}
+		}
+		entries = seeded
+	}
+
+	if historyLimit > 0 && len(entries) > historyLimit {
+		entries = entries[len(entries)-historyLimit:]
+	}
+
+	fmt.Print(renderHistory(entries, historySeeds))
+	return nil
+}
+
+// renderHistory formats entries newest first, optionally with their seeds
+func renderHistory(entries []history.Entry, showSeeds bool) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ui.CurrentTheme().Muted)
+
+	if len(entries) == 0 {
+		if showSeeds {
+			return mutedStyle.Render("No replayable runs yet") + "\n"
+		}
+		return mutedStyle.Render("No tests recorded yet — run 'zt' to start one") + "\n"
+	}
+
+	var b strings.Builder
+	header := fmt.Sprintf("%-16s %6s %7s %5s  %-10s", "date", "wpm", "acc", "time", "lang")
+	if showSeeds {
+		header += "  gen  seed"
+	}
+	b.WriteString(mutedStyle.Render(header) + "\n")
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		line := fmt.Sprintf("%-16s %6.0f %6.1f%% %4ds  %-10s",
+			entry.Timestamp.Local().Format("2006-01-02 15:04"), entry.WPM, entry.Accuracy, entry.Duration, entry.Language)
+		if showSeeds {
+			version := entry.GeneratorVersion
+			if version == 0 {
+				version = 1
+			}
+			line += fmt.Sprintf("  v%-2d %d", version, entry.Seed)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// exportHistory writes the whole history to path, or stdout for "-"
+func exportHistory(store *history.Store, path string) error {
+	if path == "-" {
+		return store.Export(os.Stdout)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export: %w", err)
+	}
+	if err := store.Export(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to export history: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	fmt.Printf("Exported history to %s\n", path)
+	return nil
+}
+
+// importHistory merges the entries of an export file into the local history
+func importHistory(store *history.Store, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read import: %w", err)
+	}
+
+	entries, err := history.ParseExport(data)
+	if err != nil {
+		return err
+	}
+
+	added, err := store.Import(entries)
+	if err != nil {
+		return fmt.Errorf("failed to import history: %w", err)
+	}
+
+	fmt.Printf("Imported %d of %d results (%d already present)\n", added, len(entries), len(entries)-added)
+	return nil
+}
+
+// verifyHistory reports problems in the history file, repairing them if asked
+func verifyHistory(store *history.Store, repair bool) error {
+	report, err := store.Verify(repair)
+	if err != nil {
+		return err
+	}
+
+	if report.OK() {
+		fmt.Printf("✓ History looks good (%d results)\n", report.Entries)
+		return nil
+	}
+
+	fmt.Printf("Checked %d results:\n", report.Entries)
+	for _, problem := range report.Problems {
+		fmt.Printf("  ✗ entry %d: %s\n", problem.Entry, problem.Issue)
+	}
+	if report.Unsorted {
+		fmt.Println("  ✗ results are not in date order")
+	}
+
+	if !report.Repaired {
+		fmt.Println("Run 'zt history --verify --repair' to fix these")
+		return nil
+	}
+	fmt.Printf("✓ Repaired: removed %d entries", len(report.Problems))
+	if report.Unsorted {
+		fmt.Print(" and sorted the rest by date")
+	}
+	fmt.Printf("\n  The original was saved to %s\n", report.BackupPath)
+	return nil
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/game"
+	"github.com/nemaniabhiram/zentype.cli/internal/ui"
+)
+
+// KeystrokeExportVersion is bumped whenever a field of the keystroke export
+// changes meaning or is removed; new fields may be added without a bump
+const KeystrokeExportVersion = 1
+
+// keystrokeExport is the JSON written by --export-keystrokes. Its text,
+// duration and keystrokes use the same fields as the transcripts read by
+// 'zt score', so an export can be scored again as it is.
+type keystrokeExport struct {
+	Version    int                 `json:"version"`
+	ExportedAt time.Time           `json:"exported_at"`
+	Language   string              `json:"language"`
+	Duration   int                 `json:"duration"` // Seconds; 0 for open-ended tests
+	Text       string              `json:"text"`     // The words reached in the test
+	Finished   bool                `json:"finished"` // False if the test was quit before it ended
+	WPM        float64             `json:"wpm"`
+	Accuracy   float64             `json:"accuracy"`
+	Keystrokes []exportedKeystroke `json:"keystrokes"`
+}
+
+// exportedKeystroke is one key press. Char is the typed character, or
+// "backspace" or "enter". Expected and Correct are left out for backspaces.
+type exportedKeystroke struct {
+	Char     string `json:"char"`
+	T        int64  `json:"t"` // Milliseconds since the first key press
+	Expected string `json:"expected,omitempty"`
+	Correct  *bool  `json:"correct,omitempty"`
+}
+
+// writeKeystrokes writes every key press of the final test to path as JSON,
+// or to stdout for "-"
+func writeKeystrokes(final ui.Model, language, path string) error {
+	stats, finished := final.FinalStats()
+	export := keystrokeExport{
+		Version:    KeystrokeExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Language:   language,
+		Duration:   duration,
+		Text:       strings.Join(final.ReachedWords(), " "),
+		Finished:   finished,
+		WPM:        stats.WPM,
+		Accuracy:   stats.Accuracy,
+		Keystrokes: []exportedKeystroke{},
+	}
+
+	for _, key := range final.Keystrokes() {
+		exported := exportedKeystroke{Char: string(key.Char), T: key.At.Milliseconds()}
+		switch key.Char {
+		case game.KeyBackspace:
+			exported.Char = "backspace"
+		case game.KeyEnter:
+			exported.Char = "enter"
+		}
+		if key.Char != game.KeyBackspace {
+			correct := key.Correct()
+			exported.Expected = string(key.Expected)
+			exported.Correct = &correct
+		}
+		export.Keystrokes = append(export.Keystrokes, exported)
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if path == "-" {
+		os.Stdout.Write(data)
+		return nil
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write keystrokes: %w", err)
+	}
+	return nil
+}
@@ -2,13 +2,32 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/nemaniabhiram/zentype.cli/internal/api"
+	"github.com/nemaniabhiram/zentype.cli/internal/auth"
+	"github.com/nemaniabhiram/zentype.cli/internal/config"
+	"github.com/nemaniabhiram/zentype.cli/internal/game"
 	"github.com/nemaniabhiram/zentype.cli/internal/ui"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 )
 
+var (
+	leaderboardActive bool     // Rank by qualifying tests played instead of WPM
+	leaderboardLocal  bool     // Show the nickname board kept on this machine
+	leaderboardHide   []string // GitHub logins to add to hidden_users
+	leaderboardUnhide []string // GitHub logins to remove from hidden_users
+	leaderboardShort  bool     // Print your rank on one line instead of the board
+)
+
+// ShortRankTimeout bounds the request made by 'zt rank --short', which is
+// meant to run from shell prompts
+const ShortRankTimeout = 2 * time.Second
+
 // leaderboardCmd represents the leaderboard command
 var leaderboardCmd = &cobra.Command{
 	Use:   "leaderboard",
@@ -19,16 +38,56 @@ Shows the top 10 players and your current rank if you're authenticated.
 To compete on the leaderboard, you need to:
 - Authenticate with GitHub using 'zentype auth'
 - Complete 60-second typing tests
-- Achieve at least 85% accuracy`,
+- Achieve at least 85% accuracy
+
+With --local, the board of nicknames that played on this machine with
+'zt --name <nickname>' is shown instead. It needs no account or server.
+
+Use --hide to leave players out of your own view of the board. Hidden logins
+are saved in ~/.zentype/config.json; --unhide shows them again.
+
+With --short, your rank, best WPM and its accuracy are printed on one line,
+e.g. "#42 • 88 WPM • 96%", for a shell prompt or status bar. Nothing is printed
+when you're offline, signed out or not ranked yet.`,
 	Example: `  zentype leaderboard
-  zentype lb`,
+  zentype lb
+  zentype leaderboard --active
+  zentype leaderboard --local
+  zentype leaderboard --hide login1,login2
+  zentype leaderboard --unhide login1
+  zentype rank --short`,
 	Aliases: []string{"lb", "rank", "top"},
 	RunE:    runLeaderboard,
 }
 
+func init() {
+	leaderboardCmd.Flags().BoolVar(&leaderboardActive, "active", false, "Rank players by qualifying tests played and longest daily streak")
+	leaderboardCmd.Flags().BoolVar(&leaderboardLocal, "local", false, "Show the local board of nicknames that played on this machine")
+	leaderboardCmd.Flags().BoolVar(&leaderboardShort, "short", false, "Print your rank on one line for shell prompts (nothing when offline or signed out)")
+	leaderboardCmd.MarkFlagsMutuallyExclusive("active", "local", "short")
+	leaderboardCmd.Flags().StringSliceVar(&leaderboardHide, "hide", nil, "Hide these GitHub logins from your leaderboard view (comma-separated)")
+	leaderboardCmd.Flags().StringSliceVar(&leaderboardUnhide, "unhide", nil, "Show previously hidden GitHub logins again")
+}
+
 func runLeaderboard(cmd *cobra.Command, args []string) error {
+	if leaderboardShort {
+		printShortRank()
+		return nil
+	}
+
+	if len(leaderboardHide) > 0 || len(leaderboardUnhide) > 0 {
+		if err := updateHiddenUsers(leaderboardHide, leaderboardUnhide); err != nil {
+			return err
+		}
+	}
+
 	// Create leaderboard model
 	model := ui.NewLeaderboardModel()
+	if leaderboardActive {
+		model = ui.NewActiveLeaderboardModel()
+	} else if leaderboardLocal {
+		model = ui.NewLocalLeaderboardModel()
+	}
 
 	// Start the TUI program
 	p := tea.NewProgram(model)
@@ -38,3 +97,52 @@ func runLeaderboard(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// printShortRank prints the user's rank on one line, e.g. "#42 • 88 WPM • 96%".
+// It prints nothing when offline, signed out or unranked, so it never breaks
+// the prompt it is embedded in.
+func printShortRank() {
+	client := api.NewClient()
+	client.SetTimeout(ShortRankTimeout)
+	authManager, err := auth.NewManager(client)
+	if err != nil || !authManager.IsAuthenticated() {
+		return
+	}
+
+	language := "english"
+	if cfg, err := config.Load(); err == nil && slices.Contains(game.Languages, cfg.Language) {
+		language = cfg.Language
+	}
+
+	stats, err := client.GetUserRank(language)
+	if err != nil || stats.Rank == 0 {
+		return
+	}
+	fmt.Printf("#%d • %.0f WPM • %.0f%%\n", stats.Rank, stats.BestWPM, stats.BestAccuracy)
+}
+
+// updateHiddenUsers adds and removes logins from hidden_users in the config
+func updateHiddenUsers(hide, unhide []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	for _, login := range hide {
+		login = strings.TrimPrefix(strings.TrimSpace(login), "@")
+		if login != "" && !cfg.IsHidden(login) {
+			cfg.HiddenUsers = append(cfg.HiddenUsers, login)
+		}
+	}
+	for _, login := range unhide {
+		login = strings.TrimPrefix(strings.TrimSpace(login), "@")
+		cfg.HiddenUsers = slices.DeleteFunc(cfg.HiddenUsers, func(hidden string) bool {
+			return strings.EqualFold(hidden, login)
+		})
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
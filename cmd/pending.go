@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/api"
+	"github.com/nemaniabhiram/zentype.cli/internal/auth"
+	"github.com/nemaniabhiram/zentype.cli/internal/game"
+	"github.com/nemaniabhiram/zentype.cli/internal/pending"
+)
+
+// retryPendingScores submits scores saved while the server was unreachable
+// and reports each outcome. Scores the server rejects are dropped; if it is
+// still unreachable the rest stay queued for the next run. Each score keeps
+// the time it was played and its idempotency key, so one that reached the
+// server on an earlier attempt is not stored twice.
+func retryPendingScores() {
+	store, err := pending.NewStore()
+	if err != nil {
+		return
+	}
+	scores, err := store.Load()
+	if err != nil || len(scores) == 0 {
+		return
+	}
+
+	client := api.NewClient()
+	authManager, err := auth.NewManager(client)
+	if err != nil || !authManager.IsAuthenticated() {
+		return // Keep them until the user signs in again
+	}
+
+	fmt.Printf("↻ Submitting %d saved score(s) from an earlier offline run...\n", len(scores))
+	for i, score := range scores {
+		stats := game.TypingStats{WPM: score.WPM, Accuracy: score.Accuracy}
+		details := api.ScoreDetails{
+			Seed:             score.Seed,
+			GeneratorVersion: score.GeneratorVersion,
+			WordDist:         score.WordDist,
+			Intervals:        score.Intervals,
+			PlayedAt:         score.Timestamp,
+			IdempotencyKey:   score.IdempotencyKey,
+		}
+		label := fmt.Sprintf("%.0f WPM, %.1f%% from %s", score.WPM, score.Accuracy, score.Timestamp.Local().Format("Jan 2 15:04"))
+
+		entry, err := client.SubmitScore(stats, score.Duration, score.Language, details)
+		if err != nil && api.IsUnreachable(err) {
+			fmt.Printf("  ⚠ Still offline; %d score(s) will be retried next time\n", len(scores)-i)
+			if err := store.Save(scores[i:]); err != nil {
+				fmt.Printf("  ✗ Failed to update saved scores: %v\n", err)
+			}
+			return
+		}
+		if err != nil {
+			fmt.Printf("  ✗ %s: %v\n", label, err)
+		} else if entry != nil && entry.Rank > 0 {
+			fmt.Printf("  ✓ %s submitted (rank #%d)\n", label, entry.Rank)
+		} else {
+			fmt.Printf("  ✓ %s submitted\n", label)
+		}
+	}
+
+	if err := store.Save(nil); err != nil {
+		fmt.Printf("  ✗ Failed to clear saved scores: %v\n", err)
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/config"
+	"github.com/nemaniabhiram/zentype.cli/internal/game"
+	"github.com/nemaniabhiram/zentype.cli/internal/ui"
+
+	"github.com/spf13/cobra"
+)
+
+// playlistCmd runs a practice routine defined in the config
+var playlistCmd = &cobra.Command{
+	Use:   "playlist [name]",
+	Short: "Run a sequence of tests defined in your config",
+	Long: `Run the tests of a playlist from the "playlists" section of
+~/.zentype/config.json in order, then show a combined summary. Without a name,
+list the configured playlists.
+
+Each step sets a duration and optionally drill characters ("chars"), n-grams
+("ngrams"), a word distribution ("word_dist") and whether an eligible result
+is submitted ("submit"):
+
+  "playlists": {
+    "warmup": [
+      {"label": "numbers", "duration": 15, "chars": "1234567890"},
+      {"label": "punctuation", "duration": 30, "chars": ".,;:'!?-()"},
+      {"label": "ranked", "duration": 60, "submit": true}
+    ]
+  }
+
+Each test starts when you press Enter. Quitting a test with Esc ends the playlist.`,
+	Example: `  zt playlist
+  zt playlist warmup`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPlaylist,
+}
+
+func init() {
+	rootCmd.AddCommand(playlistCmd)
+}
+
+func runPlaylist(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		names := cfg.PlaylistNames()
+		if len(names) == 0 {
+			fmt.Println("No playlists yet — add them to the \"playlists\" section of ~/.zentype/config.json (see 'zt playlist --help')")
+			return nil
+		}
+		for _, name := range names {
+			fmt.Printf("  %s (%d tests)\n", name, len(cfg.Playlists[name]))
+		}
+		return nil
+	}
+
+	steps, err := cfg.Playlist(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := requireTerminal(); err != nil {
+		return err
+	}
+	retryPendingScores()
+
+	legs := make([]seriesLeg, 0, len(steps))
+	for _, step := range steps {
+		legs = append(legs, playlistLeg(step, cfg))
+	}
+
+	results, err := runSeries(legs)
+	if err != nil || results == nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Print(renderSeries(fmt.Sprintf("Playlist %s", args[0]), results))
+	return nil
+}
+
+// playlistLeg builds the test for one playlist step. Drills and n-grams are
+// never submitted, like 'zt drill' and 'zt --ngrams'.
+func playlistLeg(step config.PlaylistStep, cfg *config.Config) seriesLeg {
+	leg := seriesLeg{
+		Label:    step.Describe(),
+		Duration: step.Duration,
+		Language: cfg.Language,
+		Options: ui.Options{
+			NoSubmit: !step.Submit || !cfg.SubmitScores,
+			WordDist: step.WordDist,
+		},
+	}
+	if leg.Options.WordDist == "" {
+		leg.Options.WordDist = cfg.WordDist
+	}
+
+	switch {
+	case step.Chars != "":
+		leg.Language = "drill"
+		leg.Options.Generator = game.DrillWords(step.Chars)
+	case step.Ngrams:
+		leg.Language = "ngrams"
+		leg.Options.Generator = game.NgramWords()
+	}
+	return leg
+}
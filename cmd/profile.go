@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/api"
+	"github.com/nemaniabhiram/zentype.cli/internal/auth"
+	"github.com/nemaniabhiram/zentype.cli/internal/ui"
+
+	"github.com/spf13/cobra"
+)
+
+// profileCmd represents the profile command
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage your leaderboard profile",
+	Long: `Manage how your profile appears on the global leaderboard.
+
+Private profiles keep submitting scores for your own tracking and can still
+see their rank, but are hidden from the public leaderboard.
+
+--name sets the name shown on the leaderboard instead of your GitHub login,
+and keeps it when you sign in again. An empty name goes back to your login.`,
+	Example: `  zt profile --private
+  zt profile --public
+  zt profile --name "Ada L."
+  zt profile --name ""`,
+	RunE: runProfile,
+}
+
+// MaxDisplayNameLength is the longest name 'zt profile --name' accepts, the
+// server's limit
+const MaxDisplayNameLength = 50
+
+var (
+	profilePrivate bool
+	profilePublic  bool
+	profileName    string
+)
+
+func init() {
+	profileCmd.Flags().BoolVar(&profilePrivate, "private", false, "Hide your scores from the public leaderboard")
+	profileCmd.Flags().BoolVar(&profilePublic, "public", false, "Show your scores on the public leaderboard")
+	profileCmd.Flags().StringVar(&profileName, "name", "", "Set the name shown on the leaderboard (empty for your GitHub login)")
+	profileCmd.MarkFlagsMutuallyExclusive("private", "public")
+	rootCmd.AddCommand(profileCmd)
+}
+
+func runProfile(cmd *cobra.Command, args []string) error {
+	setName := cmd.Flags().Changed("name")
+	if !profilePrivate && !profilePublic && !setName {
+		return cmd.Help()
+	}
+
+	profileName = strings.TrimSpace(profileName)
+	if utf8.RuneCountInString(profileName) > MaxDisplayNameLength {
+		return fmt.Errorf("--name must be at most %d characters", MaxDisplayNameLength)
+	}
+
+	client := api.NewClient()
+	authManager, err := auth.NewManager(client)
+	if err != nil {
+		return fmt.Errorf("failed to initialize auth manager: %w", err)
+	}
+
+	if !authManager.IsAuthenticated() {
+		fmt.Println("✗ Not authenticated")
+		fmt.Println("  Run 'zentype auth' to authenticate with GitHub")
+		return nil
+	}
+
+	if setName {
+		name, err := client.SetDisplayName(profileName)
+		if err != nil {
+			return fmt.Errorf("failed to update name: %w", err)
+		}
+		fmt.Printf("✓ You appear on the leaderboard as %s\n", ui.TruncateName(name, ui.MaxNameWidth))
+	}
+
+	if !profilePrivate && !profilePublic {
+		return nil
+	}
+	if err := client.SetVisibility(profilePublic); err != nil {
+		return fmt.Errorf("failed to update profile: %w", err)
+	}
+
+	if profilePublic {
+		fmt.Println("✓ Your profile is now public and appears on the leaderboard")
+	} else {
+		fmt.Println("✓ Your profile is now private and hidden from the leaderboard")
+		fmt.Println("  Your scores are still saved and you can see your own rank")
+	}
+	return nil
+}
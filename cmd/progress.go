@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/api"
+	"github.com/nemaniabhiram/zentype.cli/internal/auth"
+	"github.com/nemaniabhiram/zentype.cli/internal/config"
+	"github.com/nemaniabhiram/zentype.cli/internal/game"
+	"github.com/nemaniabhiram/zentype.cli/internal/history"
+	"github.com/nemaniabhiram/zentype.cli/internal/ui"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	progressSessions int  // Number of recent sessions to compare
+	progressKeys     bool // Show per-key error rate trends
+	progressRank     bool // Chart the leaderboard rank week by week
+)
+
+// progressCmd represents the progress command
+var progressCmd = &cobra.Command{
+	Use:   "progress",
+	Short: "Show how your typing has changed over recent sessions",
+	Long: `Compare the older and newer halves of your recent sessions to show whether
+you are improving.
+
+With --keys, the error rate of every key is compared instead, so you can see
+which keys you have improved on and which are getting worse. Per-key stats are
+recorded from this version on; older sessions are skipped.
+
+With --rank, chart your best WPM and leaderboard rank at the end of each week
+instead (requires authentication).`,
+	Example: `  zt progress
+  zt progress --keys
+  zt progress --rank
+  zt progress --keys -n 30`,
+	RunE: runProgress,
+}
+
+func init() {
+	progressCmd.Flags().IntVarP(&progressSessions, "sessions", "n", 10, "Number of recent sessions to compare")
+	progressCmd.Flags().BoolVar(&progressKeys, "keys", false, "Show the error rate trend of each key")
+	progressCmd.Flags().BoolVar(&progressRank, "rank", false, "Chart your leaderboard rank week by week")
+	progressCmd.MarkFlagsMutuallyExclusive("keys", "rank")
+	rootCmd.AddCommand(progressCmd)
+}
+
+func runProgress(cmd *cobra.Command, args []string) error {
+	if progressRank {
+		return runRankHistory()
+	}
+
+	if progressSessions < 2 {
+		return fmt.Errorf("need at least 2 sessions to compare")
+	}
+
+	store, err := history.NewStore()
+	if err != nil {
+		return err
+	}
+
+	entries, err := store.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load history: %w", err)
+	}
+
+	if progressKeys {
+		var withKeys []history.Entry
+		for _, entry := range entries {
+			if len(entry.Keys) > 0 {
+				withKeys = append(withKeys, entry)
+			}
+		}
+		entries = withKeys
+	}
+
+	if len(entries) > progressSessions {
+		entries = entries[len(entries)-progressSessions:]
+	}
+	if len(entries) < 2 {
+		fmt.Println(lipgloss.NewStyle().Foreground(ui.CurrentTheme().Muted).
+			Render("Not enough sessions yet — complete a few more tests and try again"))
+		return nil
+	}
+
+	// Older half vs newer half; an odd middle session counts as newer
+	earlier, recent := entries[:len(entries)/2], entries[len(entries)/2:]
+
+	if progressKeys {
+		fmt.Print(renderKeyProgress(earlier, recent))
+	} else {
+		fmt.Print(renderProgress(earlier, recent))
+	}
+	return nil
+}
+
+// runRankHistory charts the authenticated user's weekly rank
+func runRankHistory() error {
+	client := api.NewClient()
+	authManager, err := auth.NewManager(client)
+	if err != nil {
+		return err
+	}
+	if !authManager.IsAuthenticated() {
+		fmt.Println("✗ Not authenticated")
+		fmt.Println("  Run 'zentype auth' to authenticate with GitHub")
+		return nil
+	}
+
+	language := "english"
+	if cfg, err := config.Load(); err == nil && slices.Contains(game.Languages, cfg.Language) {
+		language = cfg.Language
+	}
+
+	points, err := client.GetRankHistory(language)
+	if err != nil {
+		return err
+	}
+	fmt.Print(renderRankHistory(points))
+	return nil
+}
+
+// renderRankHistory draws one bar per week, longer for a better rank, with
+// the best WPM that earned it
+func renderRankHistory(points []api.RankHistoryPoint) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ui.CurrentTheme().Muted)
+	barStyle := lipgloss.NewStyle().Foreground(ui.CurrentTheme().Hint)
+
+	if len(points) == 0 {
+		return mutedStyle.Render("No qualifying 60-second scores yet — your rank history starts with your first") + "\n"
+	}
+
+	worst := 1
+	for _, point := range points {
+		worst = max(worst, point.Rank)
+	}
+
+	var b strings.Builder
+	first, last := points[0], points[len(points)-1]
+	b.WriteString(fmt.Sprintf("Rank by week (#%d → #%d)\n", first.Rank, last.Rank))
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("%-10s %6s  %s", "week of", "rank", "best wpm")) + "\n")
+	for _, point := range points {
+		// Rank 1 fills the chart; the worst rank shown still gets one cell
+		width := 1
+		if worst > 1 {
+			width += (worst - point.Rank) * (histogramWidth - 1) / (worst - 1)
+		}
+		b.WriteString(fmt.Sprintf("%-10s %6s  %s %.0f\n",
+			point.WeekStart.Local().Format("Jan 02"), fmt.Sprintf("#%d", point.Rank),
+			barStyle.Render(strings.Repeat("█", width)), point.BestWPM))
+	}
+	return b.String()
+}
+
+// renderProgress compares the average WPM and accuracy of two groups of sessions
+func renderProgress(earlier, recent []history.Entry) string {
+	average := func(entries []history.Entry) (wpm, accuracy float64) {
+		for _, entry := range entries {
+			wpm += entry.WPM
+			accuracy += entry.Accuracy
+		}
+		n := float64(len(entries))
+		return wpm / n, accuracy / n
+	}
+
+	oldWPM, oldAcc := average(earlier)
+	newWPM, newAcc := average(recent)
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Last %d sessions (older %d vs newer %d)\n", len(earlier)+len(recent), len(earlier), len(recent)))
+	b.WriteString(fmt.Sprintf("  WPM       %6.1f → %6.1f  %s\n", oldWPM, newWPM, trendLabel(newWPM-oldWPM, 1)))
+	b.WriteString(fmt.Sprintf("  Accuracy  %5.1f%% → %5.1f%%  %s\n", oldAcc, newAcc, trendLabel(newAcc-oldAcc, 0.5)))
+	return b.String()
+}
+
+// keyTrend is the change in a key's error rate between two groups of sessions
+type keyTrend struct {
+	key         string
+	attempts    int     // Attempts across both groups
+	earlierRate float64 // Miss percentage in the older sessions
+	recentRate  float64 // Miss percentage in the newer sessions
+}
+
+// renderKeyProgress lists every key typed in both groups of sessions, worst
+// recent error rate first, with whether it is improving or getting worse
+func renderKeyProgress(earlier, recent []history.Entry) string {
+	sum := func(entries []history.Entry) map[string]history.KeyStat {
+		totals := make(map[string]history.KeyStat)
+		for _, entry := range entries {
+			for key, stat := range entry.Keys {
+				total := totals[key]
+				total.Attempts += stat.Attempts
+				total.Misses += stat.Misses
+				totals[key] = total
+			}
+		}
+		return totals
+	}
+	rate := func(stat history.KeyStat) float64 {
+		return float64(stat.Misses) / float64(stat.Attempts) * 100
+	}
+
+	old, cur := sum(earlier), sum(recent)
+	var trends []keyTrend
+	for key, now := range cur {
+		before, ok := old[key]
+		if !ok || before.Attempts == 0 || now.Attempts == 0 {
+			continue // Need data on both sides to show a trend
+		}
+		trends = append(trends, keyTrend{
+			key:         key,
+			attempts:    before.Attempts + now.Attempts,
+			earlierRate: rate(before),
+			recentRate:  rate(now),
+		})
+	}
+
+	mutedStyle := lipgloss.NewStyle().Foreground(ui.CurrentTheme().Muted)
+	if len(trends) == 0 {
+		return mutedStyle.Render("No keys typed in both older and newer sessions yet") + "\n"
+	}
+
+	sort.Slice(trends, func(i, j int) bool {
+		if trends[i].recentRate != trends[j].recentRate {
+			return trends[i].recentRate > trends[j].recentRate
+		}
+		return trends[i].key < trends[j].key
+	})
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Key error rates over the last %d sessions (older %d vs newer %d)\n",
+		len(earlier)+len(recent), len(earlier), len(recent)))
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("%-6s %8s %8s %8s  %s", "key", "typed", "before", "now", "trend")) + "\n")
+	for _, trend := range trends {
+		// Lower error rates are better, so the change is negated for the label
+		b.WriteString(fmt.Sprintf("%-6s %8d %7.1f%% %7.1f%%  %s\n",
+			keyLabel(trend.key), trend.attempts, trend.earlierRate, trend.recentRate,
+			trendLabel(trend.earlierRate-trend.recentRate, 0.5)))
+	}
+	return b.String()
+}
+
+// keyLabel names keys that would be invisible in the table
+func keyLabel(key string) string {
+	if key == " " {
+		return "space"
+	}
+	return key
+}
+
+// trendLabel describes a change where positive is better, ignoring changes
+// smaller than threshold
+func trendLabel(change, threshold float64) string {
+	switch {
+	case change >= threshold:
+		return lipgloss.NewStyle().Foreground(ui.CurrentTheme().Good).Render("improving")
+	case change <= -threshold:
+		return lipgloss.NewStyle().Foreground(ui.CurrentTheme().Error).Render("worsening")
+	default:
+		return lipgloss.NewStyle().Foreground(ui.CurrentTheme().Muted).Render("steady")
+	}
+}
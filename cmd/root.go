@@ -3,7 +3,16 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/nemaniabhiram/zentype.cli/internal/api"
+	"github.com/nemaniabhiram/zentype.cli/internal/config"
+	"github.com/nemaniabhiram/zentype.cli/internal/game"
+	"github.com/nemaniabhiram/zentype.cli/internal/history"
+	"github.com/nemaniabhiram/zentype.cli/internal/logging"
 	"github.com/nemaniabhiram/zentype.cli/internal/ui"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -15,6 +24,36 @@ var (
 	showLeaderboard bool
 	showVersion bool
 	duration    int // Duration for direct typing test
+	dumpWords   string // Destination for the words reached in the test ("-" for stdout)
+	exportKeys  string // Destination for the keystroke export ("-" for stdout)
+	focusMode   bool   // Dim everything except the current word
+	openMode    bool   // Count up with no time limit until stopped manually
+	blinkCaret  bool   // Blink the caret
+	blinkRate   int    // Caret blink interval in milliseconds
+	noSubmit    bool   // Skip leaderboard submission for this run
+	showCorrections bool // Style corrected characters differently from clean ones
+	showSpaces  string // How spaces are drawn: blank, dot or underscore
+	quietMode   bool   // Print a single stats line instead of the results screen
+	countCorrections bool // Show raw WPM/accuracy that count every keystroke
+	loopDelay   int    // Seconds to show results before starting the next test (0 = no loop)
+	debugLog    bool   // Write API, auth and error events to ~/.zentype/zentype.log
+	wordSeed    int64  // Replay the words generated from this seed
+	scrollMode  string // How the text follows the caret: line or caret
+	minimalMode bool   // Hide the timer and live stats until the test ends
+	ghostMode   bool   // Race a marker moving at your personal best pace
+	restartMode string // What Enter on the results screen does: new or same words
+	wordDist    string // How words are sampled: uniform or frequency
+	accuracyModel string // Accuracy formula: standard, final or keystrokes
+	untilWPM    int    // Keep going until this WPM is sustained (0 = off)
+	localName   string // Nickname for the local leaderboard
+	wordsLeft   bool   // Show an estimate of the words left next to the timer
+	compareAverage bool // Compare submitted results with the global averages
+	liveWPM     bool   // Show a smoothed live WPM counter next to the timer
+	themeName   string // Color theme for this run
+	ngramMode   bool   // Type pseudo-words built from common bigrams and trigrams
+	reviewMode  bool   // Pause on every new mistake
+	adaptiveMode bool  // Calibrate with a short test, then type words matched to that speed
+	testLanguage = "english" // Word list language, set with 'zt settings'
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -25,12 +64,15 @@ var rootCmd = &cobra.Command{
 	Practice your typing skills with randomized English words.`,
 	Example: `  zt             # 60-second test
   zt --time 30   # custom duration
+  zt --open      # no time limit, Ctrl+D to finish
+  zt --dump-words words.txt
   zt --leaderboard
   zt --version`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Show leaderboard if flag provided
 		if showLeaderboard {
 			if err := runLeaderboardFlag(); err != nil {
+				logging.Printf("error: %v", err)
 				fmt.Printf("Error: %v\n", err)
 				os.Exit(1)
 			}
@@ -38,7 +80,8 @@ var rootCmd = &cobra.Command{
 		}
 
 		// Otherwise run typing test (default)
-		if err := runDirectTypingTest(); err != nil {
+		if err := runDirectTypingTest(cmd); err != nil {
+			logging.Printf("error: %v", err)
 			fmt.Printf("Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -66,7 +109,19 @@ func runLeaderboardFlag() error {
 
 // Execute adds all child commands to the root command and sets flags appropriately
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	if err != nil {
+		logging.Printf("error: %v", err)
+	}
+	if warning := api.VersionWarning(); warning != "" {
+		logging.Printf("api: %s", warning)
+		// 'zt rank --short' output is embedded in shell prompts, so it stays quiet
+		if !leaderboardShort {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+	}
+	logging.Close()
+	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
@@ -78,8 +133,39 @@ func init() {
 
 	// Add --version flag with shorthand -v
 	rootCmd.PersistentFlags().BoolVarP(&showVersion, "version", "v", false, "Show the version and exit")
+	rootCmd.PersistentFlags().BoolVar(&debugLog, "debug", false, "Log API requests, auth events and errors to ~/.zentype/zentype.log")
 	rootCmd.Flags().IntVarP(&duration, "time", "t", 60, "Test duration in seconds (10-300)")
 	rootCmd.Flags().BoolVarP(&showLeaderboard, "leaderboard", "l", false, "Show the global leaderboard and exit")
+	rootCmd.Flags().BoolVar(&openMode, "open", false, "Open-ended stopwatch test; press Ctrl+D to finish")
+	rootCmd.Flags().BoolVar(&noSubmit, "no-submit", false, "Don't submit this run to the leaderboard")
+	rootCmd.Flags().BoolVar(&blinkCaret, "blink", false, "Blink the caret")
+	rootCmd.Flags().IntVar(&blinkRate, "blink-rate", 530, "Caret blink interval in milliseconds (used with --blink)")
+	rootCmd.Flags().BoolVar(&focusMode, "focus", false, "Dim everything except the word you are typing")
+	rootCmd.Flags().BoolVar(&ghostMode, "ghost", false, "Show a marker moving at your personal best pace for this duration")
+	rootCmd.Flags().BoolVar(&wordsLeft, "words-left", false, "Show an estimate of how many more words you'll type, next to the timer")
+	rootCmd.Flags().StringVar(&themeName, "theme", "", "Color theme for this run (see 'zt theme'; default from config)")
+	rootCmd.Flags().BoolVar(&liveWPM, "live-wpm", false, "Show a live WPM counter next to the timer (smoothed by wpm_smoothing in the config)")
+	rootCmd.Flags().BoolVar(&compareAverage, "compare-average", false, "Show how a submitted result compares with the average of all players")
+	rootCmd.Flags().BoolVar(&minimalMode, "minimal", false, "Hide the timer during the test; results are shown at the end")
+	rootCmd.Flags().StringVar(&scrollMode, "scroll", "line", "Scrolling style: line (current line stays on top) or caret (caret moves down the lines)")
+	rootCmd.Flags().StringVar(&showSpaces, "show-spaces", "blank", "Draw spaces as blank, dot or underscore")
+	rootCmd.Flags().Lookup("show-spaces").NoOptDefVal = "dot"
+	rootCmd.Flags().BoolVar(&showCorrections, "show-corrections", false, "Highlight characters you had to backspace and fix")
+	rootCmd.Flags().BoolVar(&countCorrections, "count-corrections", false, "Also show raw WPM and accuracy counting deleted characters and backspaces")
+	rootCmd.Flags().IntVar(&loopDelay, "loop", 0, "Start a new test automatically this many seconds after results (default 5 when no value is given)")
+	rootCmd.Flags().Lookup("loop").NoOptDefVal = "5"
+	rootCmd.Flags().BoolVarP(&quietMode, "quiet", "q", false, "Skip the results screen and print one stats line on exit")
+	rootCmd.Flags().StringVar(&restartMode, "restart", "", "Enter on the results screen starts a test with new or the same words (default from config, else new)")
+	rootCmd.Flags().StringVar(&wordDist, "word-dist", game.DistUniform, "Word sampling: uniform (every word equally often) or frequency (common words more often, like natural English)")
+	rootCmd.Flags().StringVar(&accuracyModel, "accuracy-model", game.AccuracyStandard, "Accuracy formula: standard, final (ignore corrected errors) or keystrokes (every key press, similar to MonkeyType)")
+	rootCmd.Flags().StringVar(&localName, "name", "", "Record results under this nickname on the local leaderboard ('zt leaderboard --local')")
+	rootCmd.Flags().IntVar(&untilWPM, "until-wpm", 0, "Practice with no time limit until you hold this WPM for 15 seconds")
+	rootCmd.Flags().BoolVar(&reviewMode, "review", false, "Pause briefly on every new mistake so you notice it; the pause doesn't count (never submitted or saved)")
+	rootCmd.Flags().BoolVar(&adaptiveMode, "adaptive", false, "Run a 15-second calibration test, then type easier or harder words to match your speed (never submitted)")
+	rootCmd.Flags().BoolVar(&ngramMode, "ngrams", false, "Type pseudo-words built from common English bigrams and trigrams (never submitted)")
+	rootCmd.Flags().Int64Var(&wordSeed, "seed", 0, "Type the words generated from this seed (see 'zt history --seeds')")
+	rootCmd.Flags().StringVar(&dumpWords, "dump-words", "", "Write the words reached in the test to a file ('-' for stdout)")
+	rootCmd.Flags().StringVar(&exportKeys, "export-keystrokes", "", "Write every keystroke with its timing, expected character and correctness to a JSON file ('-' for stdout)")
 
 	// Add subcommands
 	rootCmd.AddCommand(leaderboardCmd)
@@ -91,24 +177,300 @@ func init() {
 			fmt.Println("zentype version", version)
 			os.Exit(0)
 		}
+
+		if debugLog {
+			if err := logging.Enable(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: debug log disabled: %v\n", err)
+				return
+			}
+			logging.Printf("zentype %s started: %v", version, os.Args[1:])
+		}
+
+		// Every screen uses the configured theme; --theme overrides it for a test
+		if cfg, err := config.Load(); err == nil {
+			ui.ApplyTheme(cfg.Theme)
+		}
 	})
 }
 
+// applyConfigDefaults fills in test settings saved with 'zt settings' for any
+// flag that wasn't given on the command line
+func applyConfigDefaults(cmd *cobra.Command, cfg *config.Config) {
+	flags := cmd.Flags()
+	if !flags.Changed("time") {
+		duration = cfg.Duration
+	}
+	if slices.Contains(game.Languages, cfg.Language) {
+		testLanguage = cfg.Language
+	}
+	if !flags.Changed("word-dist") && cfg.WordDist != "" {
+		wordDist = cfg.WordDist
+	}
+	if !flags.Changed("blink") {
+		blinkCaret = cfg.Blink
+	}
+	if !flags.Changed("focus") {
+		focusMode = cfg.Focus
+	}
+	if !flags.Changed("minimal") {
+		minimalMode = cfg.Minimal
+	}
+	if !flags.Changed("words-left") {
+		wordsLeft = cfg.WordsLeft
+	}
+	if !flags.Changed("live-wpm") {
+		liveWPM = cfg.LiveWPM
+	}
+	if !flags.Changed("compare-average") {
+		compareAverage = cfg.CompareAverage
+	}
+}
+
 // runDirectTypingTest runs a typing test directly from the root command
-func runDirectTypingTest() error {
-	// Validate duration
-	if duration < 10 || duration > 300 {
+func runDirectTypingTest(cmd *cobra.Command) error {
+	if cfg, err := config.Load(); err == nil {
+		applyConfigDefaults(cmd, cfg)
+	}
+
+	localName = strings.TrimSpace(localName)
+	if utf8.RuneCountInString(localName) > 20 {
+		return fmt.Errorf("--name must be at most 20 characters")
+	}
+
+	if cmd.Flags().Changed("until-wpm") && (untilWPM < 1 || untilWPM > 300) {
+		return fmt.Errorf("--until-wpm must be between 1 and 300")
+	}
+	if untilWPM > 0 && cmd.Flags().Changed("time") {
+		return fmt.Errorf("--until-wpm and --time cannot be used together")
+	}
+
+	// Open-ended tests use Duration == 0 to mean "no limit"
+	if openMode || untilWPM > 0 {
+		duration = 0
+	} else if duration < 10 || duration > 300 {
 		return fmt.Errorf("duration must be between 10 and 300 seconds")
 	}
 
 	// Create a new typing test model
-	model := ui.NewModel(duration, "english")
+	if blinkCaret && blinkRate < 100 {
+		return fmt.Errorf("blink rate must be at least 100 milliseconds")
+	}
+
+	if loopDelay < 0 {
+		return fmt.Errorf("loop delay must not be negative")
+	}
+	if loopDelay > 0 && quietMode {
+		return fmt.Errorf("--loop and --quiet cannot be used together")
+	}
+
+	caretScroll, ok := ui.ScrollModes[scrollMode]
+	if !ok {
+		return fmt.Errorf("invalid --scroll value %q: use line or caret", scrollMode)
+	}
+
+	if restartMode != "" && !config.ValidRestartMode(restartMode) {
+		return fmt.Errorf("invalid --restart value %q: use new or same", restartMode)
+	}
+
+	if ngramMode && cmd.Flags().Changed("seed") {
+		return fmt.Errorf("--ngrams and --seed cannot be used together")
+	}
+	if adaptiveMode && (ngramMode || cmd.Flags().Changed("seed")) {
+		return fmt.Errorf("--adaptive cannot be used with --ngrams or --seed")
+	}
+
+	if wordDist != game.DistUniform && wordDist != game.DistFrequency {
+		return fmt.Errorf("invalid --word-dist value %q: use %s", wordDist, strings.Join(game.WordDists, " or "))
+	}
+
+	if themeName != "" {
+		if err := ui.ApplyTheme(themeName); err != nil {
+			return fmt.Errorf("invalid --theme value: %w", err)
+		}
+	}
+
+	if !slices.Contains(game.AccuracyModels, accuracyModel) {
+		return fmt.Errorf("invalid --accuracy-model value %q: use %s", accuracyModel, strings.Join(game.AccuracyModels, ", "))
+	}
+
+	spaceGlyph, ok := ui.SpaceGlyphs[showSpaces]
+	if !ok {
+		return fmt.Errorf("invalid --show-spaces value %q: use blank, dot or underscore", showSpaces)
+	}
+
+	if err := requireTerminal(); err != nil {
+		return err
+	}
+
+	if !quietMode {
+		if ran, err := offerTutorial(); ran || err != nil {
+			return err
+		}
+	}
+
+	retryPendingScores()
+
+	// Review pauses stop the clock, which would inflate the WPM, so review runs
+	// are neither submitted nor saved (see Model.finishTest)
+	if reviewMode {
+		noSubmit = true
+	}
+
+	// N-gram drills aren't words, so like 'zt drill' they are never submitted
+	language := testLanguage
+	var generator game.WordGenerator
+	if ngramMode {
+		language = "ngrams"
+		generator = game.NgramWords()
+		noSubmit = true
+	}
+
+	// Adaptive tests draw from part of the word list, so they aren't submitted either
+	notice := seedVersionNotice(wordSeed, wordDist)
+	var adaptive ui.Adaptive
+	if adaptiveMode {
+		var err error
+		generator, adaptive, err = calibrate(language, wordDist)
+		if err != nil || generator == nil {
+			return err
+		}
+		notice = adaptive.String()
+		language = "adaptive"
+		noSubmit = true
+	}
+
+	model, err := ui.NewModelWithOptions(duration, language, ui.Options{
+		Focus:     focusMode,
+		Blink:     blinkCaret,
+		BlinkRate: time.Duration(blinkRate) * time.Millisecond,
+		NoSubmit:  noSubmit,
+		ShowCorrections: showCorrections,
+		SpaceGlyph: spaceGlyph,
+		Quiet:     quietMode,
+		CountCorrections: countCorrections,
+		LoopDelay: time.Duration(loopDelay) * time.Second,
+		Seed:      wordSeed,
+		Notice:    notice,
+		CaretScroll: caretScroll,
+		Minimal:   minimalMode,
+		Ghost:     ghostMode,
+		RestartMode: restartMode,
+		WordDist:  wordDist,
+		AccuracyModel: accuracyModel,
+		UntilWPM:  float64(untilWPM),
+		LocalName: localName,
+		WordsLeft: wordsLeft,
+		CompareAverage: compareAverage,
+		LiveWPM:   liveWPM,
+		Generator: generator,
+		Review:    reviewMode,
+		Adaptive:  adaptive,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create typing test: %w", err)
+	}
 
 	// Start the TUI program without alternate screen for faster startup
 	p := tea.NewProgram(model)
-	if _, err := p.Run(); err != nil {
+	finalModel, err := p.Run()
+	if err != nil {
 		return fmt.Errorf("error running typing test: %w", err)
 	}
 
+	final, ok := asUIModel(finalModel)
+	if !ok {
+		return nil
+	}
+
+	if quietMode {
+		if stats, finished := final.FinalStats(); finished {
+			fmt.Println(formatQuietStats(stats, language))
+		}
+	}
+
+	if exportKeys != "" {
+		if err := writeKeystrokes(final, language, exportKeys); err != nil {
+			return err
+		}
+	}
+
+	if dumpWords != "" {
+		return writeReachedWords(final, dumpWords)
+	}
+
+	return nil
+}
+
+// seedVersionNotice warns when seed was last used with a different word
+// generator or distribution, in which case replaying it won't give the original words
+func seedVersionNotice(seed int64, dist string) string {
+	if seed == 0 {
+		return ""
+	}
+	store, err := history.NewStore()
+	if err != nil {
+		return ""
+	}
+	entries, err := store.Load()
+	if err != nil {
+		return ""
+	}
+	entry, ok := history.FindSeed(entries, seed)
+	if !ok {
+		return ""
+	}
+
+	version := entry.GeneratorVersion
+	if version == 0 {
+		version = 1
+	}
+	if version != game.GeneratorVersion {
+		return fmt.Sprintf("This seed was created with generator v%d; words may differ", version)
+	}
+
+	recorded := entry.WordDist
+	if recorded == "" {
+		recorded = game.DistUniform
+	}
+	if recorded != dist {
+		return fmt.Sprintf("This seed was played with --word-dist %s; words will differ", recorded)
+	}
+	return ""
+}
+
+// asUIModel extracts the typing test model returned by the program, which may
+// be a value or a pointer depending on whether Update ran
+func asUIModel(finalModel tea.Model) (ui.Model, bool) {
+	switch m := finalModel.(type) {
+	case ui.Model:
+		return m, true
+	case *ui.Model:
+		return *m, true
+	}
+	return ui.Model{}, false
+}
+
+// formatQuietStats formats the one-line summary printed by --quiet
+func formatQuietStats(stats game.TypingStats, language string) string {
+	if stats.Invalid != "" {
+		return fmt.Sprintf("INVALID (%s) | %ds | %s", stats.Invalid, int(stats.TimeElapsed.Seconds()), language)
+	}
+	return fmt.Sprintf("WPM %.0f | ACC %.0f%% | %ds | %s",
+		stats.WPM, stats.Accuracy, int(stats.TimeElapsed.Seconds()), language)
+}
+
+// writeReachedWords writes the words reached in the final test to path, or stdout for "-"
+func writeReachedWords(final ui.Model, path string) error {
+	words := final.ReachedWords()
+
+	text := strings.Join(words, " ") + "\n"
+	if path == "-" {
+		fmt.Print(text)
+		return nil
+	}
+
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		return fmt.Errorf("failed to write words: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/game"
+
+	"github.com/spf13/cobra"
+)
+
+var transcriptPath string // Path of the transcript to score ("-" for stdin)
+
+// scoreCmd represents the headless score command
+var scoreCmd = &cobra.Command{
+	Use:   "score",
+	Short: "Score a recorded transcript without the TUI",
+	Long: `Replay a recorded transcript through the typing engine and print the
+resulting stats as JSON, so external tools get the same WPM and accuracy
+as an interactive test.
+
+The transcript is a JSON object:
+
+  {
+    "text": "the expected words",
+    "duration": 60,
+    "keystrokes": [{"char": "t", "t": 0}, {"char": "h", "t": 180}]
+  }
+
+"t" is the time of each keystroke in milliseconds. "char" is a single
+character, or "backspace" / "enter". The test ends at the last keystroke,
+or after "duration" seconds if the keystrokes go on longer. A duration of 0
+scores the transcript as an open-ended test.`,
+	Example: `  zt score --transcript run.json
+  cat run.json | zt score --transcript -`,
+	Args: cobra.NoArgs,
+	RunE: runScore,
+}
+
+func init() {
+	scoreCmd.Flags().StringVar(&transcriptPath, "transcript", "", "Transcript JSON file to score (\"-\" for stdin)")
+	scoreCmd.MarkFlagRequired("transcript")
+	rootCmd.AddCommand(scoreCmd)
+}
+
+// transcript is the input format of zt score
+type transcript struct {
+	Text       string                `json:"text"`
+	Duration   int                   `json:"duration"`
+	Keystrokes []transcriptKeystroke `json:"keystrokes"`
+}
+
+type transcriptKeystroke struct {
+	Char string `json:"char"`
+	T    int64  `json:"t"`
+}
+
+// scoreResult is the JSON output of zt score
+type scoreResult struct {
+	WPM               float64 `json:"wpm"`
+	Accuracy          float64 `json:"accuracy"`
+	CharactersTyped   int     `json:"characters_typed"`
+	CorrectChars      int     `json:"correct_chars"`
+	UncorrectedErrors int     `json:"uncorrected_errors"`
+	ErrorsPerMinute   float64 `json:"errors_per_minute"`
+	TimeElapsed       float64 `json:"time_elapsed"`
+}
+
+func runScore(cmd *cobra.Command, args []string) error {
+	var data []byte
+	var err error
+	if transcriptPath == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(transcriptPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read transcript: %w", err)
+	}
+
+	var t transcript
+	if err := json.Unmarshal(data, &t); err != nil {
+		return fmt.Errorf("failed to parse transcript: %w", err)
+	}
+	if t.Duration < 0 || t.Duration > 300 {
+		return fmt.Errorf("duration must be between 0 and 300 seconds")
+	}
+
+	keystrokes, err := parseKeystrokes(t.Keystrokes)
+	if err != nil {
+		return err
+	}
+
+	g, err := game.NewReplayGame(t.Duration, t.Text)
+	if err != nil {
+		return err
+	}
+	stats := g.Replay(keystrokes)
+
+	out, err := json.MarshalIndent(scoreResult{
+		WPM:               stats.WPM,
+		Accuracy:          stats.Accuracy,
+		CharactersTyped:   stats.CharactersTyped,
+		CorrectChars:      stats.CorrectChars,
+		UncorrectedErrors: stats.UncorrectedErrors,
+		ErrorsPerMinute:   stats.ErrorsPerMinute,
+		TimeElapsed:       stats.TimeElapsed.Seconds(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// parseKeystrokes converts transcript keystrokes to engine keystrokes,
+// rejecting entries that aren't a single key or go back in time
+func parseKeystrokes(raw []transcriptKeystroke) ([]game.Keystroke, error) {
+	keystrokes := make([]game.Keystroke, 0, len(raw))
+	var last int64
+	for i, k := range raw {
+		if k.T < last {
+			return nil, fmt.Errorf("keystroke %d: timestamps must not decrease", i)
+		}
+		last = k.T
+
+		var char rune
+		switch k.Char {
+		case "backspace":
+			char = game.KeyBackspace
+		case "enter":
+			char = game.KeyEnter
+		default:
+			if utf8.RuneCountInString(k.Char) != 1 {
+				return nil, fmt.Errorf("keystroke %d: %q is not a single character", i, k.Char)
+			}
+			char, _ = utf8.DecodeRuneInString(k.Char)
+		}
+
+		keystrokes = append(keystrokes, game.Keystroke{
+			Char: char,
+			At:   time.Duration(k.T) * time.Millisecond,
+		})
+	}
+	return keystrokes, nil
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+// settingsCmd represents the settings editor command
+var settingsCmd = &cobra.Command{
+	Use:   "settings",
+	Short: "Edit your preferences interactively",
+	Long: `Open a small form to change the defaults stored in ~/.zentype/config.json,
+such as the test duration, word distribution, caret and display modes, and
+score submission. Command-line flags still override these per run.`,
+	Example: `  zt settings`,
+	RunE:    runSettings,
+}
+
+func init() {
+	rootCmd.AddCommand(settingsCmd)
+}
+
+func runSettings(cmd *cobra.Command, args []string) error {
+	if err := requireTerminal(); err != nil {
+		return err
+	}
+
+	p := tea.NewProgram(ui.NewSettingsModel())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("error running settings: %w", err)
+	}
+	return nil
+}
@@ -38,8 +38,15 @@ func runTypingTest(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("duration must be between 10 and 300 seconds (e.g., --time 60)")
 	}
 
+	if err := requireTerminal(); err != nil {
+		return err
+	}
+
 	// Create a new typing test model
-	model := ui.NewModel(startDuration, "english")
+	model, err := ui.NewModel(startDuration, "english")
+	if err != nil {
+		return fmt.Errorf("failed to create typing test: %w", err)
+	}
 
 	// Start the TUI program without alternate screen for faster startup
 	p := tea.NewProgram(model)
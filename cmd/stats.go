@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/api"
+	"github.com/nemaniabhiram/zentype.cli/internal/auth"
+	"github.com/nemaniabhiram/zentype.cli/internal/config"
+	"github.com/nemaniabhiram/zentype.cli/internal/game"
+	"github.com/nemaniabhiram/zentype.cli/internal/ui"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// histogramWidth is the length of the longest bar in the distribution chart
+const histogramWidth = 40
+
+var statsLanguages bool // Compare languages instead of showing the WPM histogram
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show where you stand among all players",
+	Long: `Show a histogram of every player's best 60-second WPM.
+If you're authenticated, the bucket containing your best score is marked and
+your qualifying tests and best WPM this week are compared with last week.
+
+With --languages, compare player counts, average WPM and accuracy per language.`,
+	Example: `  zt stats
+  zt stats --languages`,
+	RunE: runStats,
+}
+
+func init() {
+	statsCmd.Flags().BoolVar(&statsLanguages, "languages", false, "Compare average WPM, accuracy and players per language")
+	rootCmd.AddCommand(statsCmd)
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	client := api.NewClient()
+
+	if statsLanguages {
+		languages, err := client.GetLanguageStats()
+		if err != nil {
+			return fmt.Errorf("failed to load language stats: %w", err)
+		}
+		fmt.Print(renderLanguageStats(languages))
+		return nil
+	}
+
+	language := "english"
+	if cfg, err := config.Load(); err == nil && slices.Contains(game.Languages, cfg.Language) {
+		language = cfg.Language
+	}
+
+	distribution, err := client.GetDistribution(language)
+	if err != nil {
+		return fmt.Errorf("failed to load distribution: %w", err)
+	}
+
+	// Find the user's best score to mark their bucket
+	bestWPM := -1.0
+	var summary *api.UserSummary
+	if authManager, err := auth.NewManager(client); err == nil && authManager.IsAuthenticated() {
+		if stats, err := client.GetUserRank(language); err == nil && stats.QualifiedScores > 0 {
+			bestWPM = stats.BestWPM
+		}
+		summary, _ = client.GetUserSummary(language)
+	}
+
+	fmt.Print(renderDistribution(distribution, bestWPM))
+	if summary != nil {
+		fmt.Println()
+		fmt.Print(renderUserSummary(summary))
+	}
+	return nil
+}
+
+// renderUserSummary compares the user's qualifying tests and best WPM this
+// week with last week and their best ever
+func renderUserSummary(summary *api.UserSummary) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ui.CurrentTheme().Muted)
+	rollStyle := lipgloss.NewStyle().Foreground(ui.CurrentTheme().Good).Bold(true)
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("This week: %d qualifying tests %s\n", summary.QualifiedThisWeek,
+		mutedStyle.Render(fmt.Sprintf("(last week %d)", summary.QualifiedLastWeek))))
+	if summary.BestWPMThisWeek > 0 {
+		b.WriteString(fmt.Sprintf("Best this week: %.0f WPM %s\n", summary.BestWPMThisWeek,
+			mutedStyle.Render(fmt.Sprintf("(all-time %.0f)", summary.BestWPMAllTime))))
+	}
+
+	switch {
+	case summary.BestWPMThisWeek > 0 && summary.BestWPMThisWeek >= summary.BestWPMAllTime:
+		b.WriteString(rollStyle.Render("🔥 You set your all-time best this week!") + "\n")
+	case summary.QualifiedLastWeek > 0 && summary.QualifiedThisWeek > summary.QualifiedLastWeek:
+		b.WriteString(rollStyle.Render("🔥 You're on a roll — more tests than last week already") + "\n")
+	case summary.QualifiedThisWeek == 0:
+		b.WriteString(mutedStyle.Render("No qualifying tests yet this week — run 'zt' to get started") + "\n")
+	}
+	return b.String()
+}
+
+// renderDistribution draws the WPM histogram, marking the bucket containing bestWPM (if >= 0)
+func renderDistribution(distribution *api.WPMDistribution, bestWPM float64) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.CurrentTheme().Accent)
+	mutedStyle := lipgloss.NewStyle().Foreground(ui.CurrentTheme().Muted)
+	barStyle := lipgloss.NewStyle().Foreground(ui.CurrentTheme().Hint)
+	youStyle := lipgloss.NewStyle().Foreground(ui.CurrentTheme().Warn).Bold(true)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("WPM distribution • %s • %d players", distribution.Language, distribution.TotalUsers)))
+	b.WriteString("\n\n")
+
+	if len(distribution.Buckets) == 0 {
+		b.WriteString(mutedStyle.Render("No qualifying scores yet"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	maxCount := 0
+	for _, bucket := range distribution.Buckets {
+		if bucket.Count > maxCount {
+			maxCount = bucket.Count
+		}
+	}
+
+	for _, bucket := range distribution.Buckets {
+		barLen := 0
+		if maxCount > 0 {
+			barLen = bucket.Count * histogramWidth / maxCount
+		}
+		if bucket.Count > 0 && barLen == 0 {
+			barLen = 1
+		}
+
+		label := mutedStyle.Render(fmt.Sprintf("%4d-%-4d", bucket.MinWPM, bucket.MaxWPM))
+		bar := barStyle.Render(strings.Repeat("█", barLen))
+		isYours := bestWPM >= float64(bucket.MinWPM) && bestWPM < float64(bucket.MaxWPM)
+		if isYours {
+			bar = youStyle.Render(strings.Repeat("█", barLen))
+		}
+
+		line := fmt.Sprintf("%s %s %d", label, bar, bucket.Count)
+		if isYours {
+			line += youStyle.Render("  ◀ you")
+		}
+		b.WriteString(line + "\n")
+	}
+
+	return b.String()
+}
+
+// renderLanguageStats draws a comparison table of per-language averages
+func renderLanguageStats(languages []api.LanguageStats) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.CurrentTheme().Accent)
+	mutedStyle := lipgloss.NewStyle().Foreground(ui.CurrentTheme().Muted)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Languages • qualifying 60-second tests"))
+	b.WriteString("\n\n")
+
+	if len(languages) == 0 {
+		b.WriteString(mutedStyle.Render("No qualifying scores yet"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("%-12s %8s %8s %8s %8s %8s", "language", "players", "tests", "avg wpm", "avg acc", "best")))
+	b.WriteString("\n")
+	for _, language := range languages {
+		b.WriteString(fmt.Sprintf("%-12s %8d %8d %8.1f %7.1f%% %8.0f\n",
+			language.Language, language.Users, language.Scores,
+			language.AverageWPM, language.AverageAccuracy, language.BestWPM))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/config"
+	"github.com/nemaniabhiram/zentype.cli/internal/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var themePreview string // Theme to render a sample of
+
+// themeCmd lists the color themes and previews them
+var themeCmd = &cobra.Command{
+	Use:   "theme",
+	Short: "List color themes or preview one",
+	Long: `List the built-in color themes, or preview one with --preview.
+
+The preview draws a sample typing screen and results screen in the theme and
+exits, without starting a test. Use a theme for one run with 'zt --theme <name>'
+or for every run by choosing it in 'zt settings'.`,
+	Example: `  zt theme
+  zt theme --preview light`,
+	RunE: runTheme,
+}
+
+func init() {
+	themeCmd.Flags().StringVar(&themePreview, "preview", "", "Draw a sample typing and results screen in this theme")
+	rootCmd.AddCommand(themeCmd)
+}
+
+func runTheme(cmd *cobra.Command, args []string) error {
+	if themePreview != "" {
+		preview, err := ui.RenderThemePreview(themePreview)
+		if err != nil {
+			return err
+		}
+		fmt.Println(preview)
+		return nil
+	}
+
+	current := ui.DefaultTheme
+	if cfg, err := config.Load(); err == nil && cfg.Theme != "" {
+		current = cfg.Theme
+	}
+	for _, name := range ui.ThemeNames {
+		marker := "  "
+		if name == current {
+			marker = "• "
+		}
+		fmt.Println(marker + name)
+	}
+	fmt.Println()
+	fmt.Println("Preview one with 'zt theme --preview <name>'")
+	return nil
+}
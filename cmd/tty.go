@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+)
+
+// errNoTerminal explains why an interactive test can't run and what to use instead
+var errNoTerminal = errors.New(`zt needs an interactive terminal for typing tests, but stdin or stdout is not a TTY.
+  To score a recorded run in scripts or CI, use 'zt score --transcript <file>'`)
+
+// requireTerminal returns errNoTerminal unless both stdin and stdout are
+// attached to a terminal, so we fail clearly instead of inside Bubble Tea
+func requireTerminal() error {
+	for _, f := range []*os.File{os.Stdin, os.Stdout} {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return errNoTerminal
+		}
+	}
+	return nil
+}
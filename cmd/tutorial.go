@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/config"
+	"github.com/nemaniabhiram/zentype.cli/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+// tutorialCmd walks a new user through a short guided test
+var tutorialCmd = &cobra.Command{
+	Use:   "tutorial",
+	Short: "Take a short guided first test",
+	Long: `Walk through a short guided typing test with tips on screen, then learn
+what the results mean and where to go next.
+
+The first time zt runs it offers the tutorial; it is only offered once.`,
+	RunE: runTutorial,
+}
+
+func init() {
+	rootCmd.AddCommand(tutorialCmd)
+}
+
+func runTutorial(cmd *cobra.Command, args []string) error {
+	if err := requireTerminal(); err != nil {
+		return err
+	}
+
+	fmt.Println("👋 Welcome to ZenType!")
+	fmt.Println()
+	fmt.Printf("You'll type for %d seconds with tips on screen. Nothing is submitted to the leaderboard.\n", ui.TutorialDuration)
+	fmt.Print("Press Enter to begin...")
+	bufio.NewReader(os.Stdin).ReadString('\n')
+
+	model, err := ui.NewModelWithOptions(ui.TutorialDuration, "english", ui.Options{NoSubmit: true, Tutorial: true})
+	if err != nil {
+		return fmt.Errorf("failed to create typing test: %w", err)
+	}
+	if _, err := tea.NewProgram(model).Run(); err != nil {
+		return fmt.Errorf("error running typing test: %w", err)
+	}
+
+	fmt.Println("🎉 That's it! Where to go next:")
+	fmt.Println("  zt                  a 60-second test, the length the leaderboard ranks")
+	fmt.Println("  zt auth             sign in with GitHub to submit your scores")
+	fmt.Println("  zt leaderboard      see how you compare with other players")
+	fmt.Println("  zt settings         change the default duration, caret and display")
+	fmt.Println("  zt --help           every other flag and command")
+
+	return markTutorialDone()
+}
+
+// offerTutorial asks a first-time user whether to take the tutorial before
+// their first test, and reports whether it ran. Either answer is remembered so
+// the offer is only made once.
+func offerTutorial() (bool, error) {
+	if cfg, err := config.Load(); err != nil || cfg.TutorialDone {
+		return false, nil
+	}
+
+	fmt.Printf("👋 First time using zt? Take the %d-second tutorial first? [Y/n] ", ui.TutorialDuration)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer != "" && answer != "y" && answer != "yes" {
+		fmt.Println("  Run 'zt tutorial' any time to take it later")
+		return false, markTutorialDone()
+	}
+	return true, runTutorial(tutorialCmd, nil)
+}
+
+// markTutorialDone records in the config that the tutorial was offered
+func markTutorialDone() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	cfg.TutorialDone = true
+	return cfg.Save()
+}
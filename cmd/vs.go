@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/api"
+	"github.com/nemaniabhiram/zentype.cli/internal/auth"
+	"github.com/nemaniabhiram/zentype.cli/internal/ui"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// vsCmd represents the head-to-head comparison command
+var vsCmd = &cobra.Command{
+	Use:   "vs <github-login>",
+	Short: "Compare your stats head-to-head with another player",
+	Long: `Compare your best 60-second score with another player's public stats.
+The leader in each metric is highlighted.`,
+	Example: `  zt vs octocat`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runVs,
+}
+
+func init() {
+	rootCmd.AddCommand(vsCmd)
+}
+
+func runVs(cmd *cobra.Command, args []string) error {
+	client := api.NewClient()
+	authManager, err := auth.NewManager(client)
+	if err != nil {
+		return fmt.Errorf("failed to initialize auth manager: %w", err)
+	}
+
+	if !authManager.IsAuthenticated() {
+		fmt.Println("✗ Not authenticated")
+		fmt.Println("  Run 'zentype auth' to authenticate with GitHub")
+		return nil
+	}
+
+	mine, err := client.GetUserRank("english")
+	if err != nil {
+		return fmt.Errorf("failed to load your stats: %w", err)
+	}
+
+	theirs, err := client.GetPublicProfile(args[0], "english")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(renderComparison(mine, theirs))
+	return nil
+}
+
+// comparisonRow describes one metric in the head-to-head table
+type comparisonRow struct {
+	label        string
+	mine, theirs string
+	// lead is 1 if I lead, -1 if they lead, 0 for a tie or no comparison
+	lead int
+}
+
+// renderComparison draws a side-by-side table of two players' stats
+func renderComparison(mine, theirs *api.UserStats) string {
+	mutedStyle := lipgloss.NewStyle().Foreground(ui.CurrentTheme().Muted)
+	leadStyle := lipgloss.NewStyle().Foreground(ui.CurrentTheme().Warn).Bold(true)
+	labelStyle := lipgloss.NewStyle().Width(18).Align(lipgloss.Left)
+	valueStyle := lipgloss.NewStyle().Width(16).Align(lipgloss.Right)
+
+	mineQualified := mine.QualifiedScores > 0
+	theirsQualified := theirs.QualifiedScores > 0
+
+	rows := []comparisonRow{
+		{
+			label:  "Best WPM",
+			mine:   formatIfQualified(mineQualified, "%.0f", mine.BestWPM),
+			theirs: formatIfQualified(theirsQualified, "%.0f", theirs.BestWPM),
+			lead:   compareFloat(mineQualified, theirsQualified, mine.BestWPM, theirs.BestWPM),
+		},
+		{
+			label:  "Accuracy",
+			mine:   formatIfQualified(mineQualified, "%.1f%%", mine.BestAccuracy),
+			theirs: formatIfQualified(theirsQualified, "%.1f%%", theirs.BestAccuracy),
+			lead:   compareFloat(mineQualified, theirsQualified, mine.BestAccuracy, theirs.BestAccuracy),
+		},
+		{
+			label:  "Rank",
+			mine:   formatRank(mine.Rank),
+			theirs: formatRank(theirs.Rank),
+			// Lower rank is better; unranked players always trail
+			lead: compareFloat(mine.Rank > 0, theirs.Rank > 0, float64(-mine.Rank), float64(-theirs.Rank)),
+		},
+		{
+			label:  "Qualifying games",
+			mine:   fmt.Sprintf("%d", mine.QualifiedScores),
+			theirs: fmt.Sprintf("%d", theirs.QualifiedScores),
+			lead:   compareFloat(true, true, float64(mine.QualifiedScores), float64(theirs.QualifiedScores)),
+		},
+	}
+
+	var lines []string
+	lines = append(lines, lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		labelStyle.Render(""),
+		mutedStyle.Copy().Inherit(valueStyle).Render(ui.TruncateName(mine.Username, 14)),
+		mutedStyle.Copy().Inherit(valueStyle).Render(ui.TruncateName(theirs.Username, 14)),
+	))
+	lines = append(lines, mutedStyle.Render(strings.Repeat("─", 50)))
+
+	for _, row := range rows {
+		mineCell := valueStyle.Render(row.mine)
+		theirsCell := valueStyle.Render(row.theirs)
+		if row.lead > 0 {
+			mineCell = leadStyle.Copy().Inherit(valueStyle).Render(row.mine)
+		} else if row.lead < 0 {
+			theirsCell = leadStyle.Copy().Inherit(valueStyle).Render(row.theirs)
+		}
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render(row.label), mineCell, theirsCell))
+	}
+
+	if !mineQualified || !theirsQualified {
+		lines = append(lines, "")
+		if !mineQualified {
+			lines = append(lines, mutedStyle.Render("You have no qualifying scores yet — play a 60s test with 85%+ accuracy"))
+		}
+		if !theirsQualified {
+			lines = append(lines, mutedStyle.Render(fmt.Sprintf("%s has no qualifying scores yet", ui.TruncateName(theirs.Username, ui.MaxNameWidth))))
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// compareFloat returns 1 if a leads, -1 if b leads and 0 otherwise. A side that
+// has no value (ok == false) never leads.
+func compareFloat(aOK, bOK bool, a, b float64) int {
+	switch {
+	case aOK && !bOK:
+		return 1
+	case !aOK && bOK:
+		return -1
+	case !aOK && !bOK, a == b:
+		return 0
+	case a > b:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// formatIfQualified formats value, or a dash when the player has no qualifying scores
+func formatIfQualified(qualified bool, format string, value float64) string {
+	if !qualified {
+		return "—"
+	}
+	return fmt.Sprintf(format, value)
+}
+
+// formatRank formats a rank, or a dash for unranked players
+func formatRank(rank int) string {
+	if rank <= 0 {
+		return "—"
+	}
+	return fmt.Sprintf("#%d", rank)
+}
@@ -2,20 +2,47 @@ package api
 
 import (
 	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
 	"github.com/nemaniabhiram/zentype.cli/internal/game"
+	"github.com/nemaniabhiram/zentype.cli/internal/logging"
 )
 
 const (
-	// Default API base URL - can be overridden via environment variable
-	DefaultBaseURL = "https://zentypecli-production.up.railway.app/api"
+	// Default API host and path prefix - can be overridden via environment variables
+	DefaultHost    = "https://zentypecli-production.up.railway.app"
+	DefaultPrefix  = "/api"
+	DefaultBaseURL = DefaultHost + DefaultPrefix
 	Timeout        = 15 * time.Second
+
+	// DefaultLeaderboardLimit is the number of entries requested when no limit is given
+	DefaultLeaderboardLimit = 10
+
+	// APIVersion is the server API version this client was built against. The
+	// server reports its own in APIVersionHeader; servers that predate the
+	// header are version 1.
+	APIVersion       = 2
+	APIVersionHeader = "X-Zentype-API-Version"
 )
 
+// serverAPIVersion is the API version reported by the last server response, 0 if none yet
+var serverAPIVersion atomic.Int64
+
 // LeaderboardEntry represents a leaderboard entry
 type LeaderboardEntry struct {
 	ID        int       `json:"id,omitempty"`
@@ -27,6 +54,33 @@ type LeaderboardEntry struct {
 	Language  string    `json:"language"`
 	CreatedAt time.Time `json:"created_at"`
 	Rank      int       `json:"rank,omitempty"`
+
+	// GitHub handle; empty from older servers
+	GitHubLogin string `json:"github_login,omitempty"`
+
+	// Mode is "standard" or "daily"; daily scores also carry their challenge date
+	Mode          string `json:"mode,omitempty"`
+	ChallengeDate string `json:"challenge_date,omitempty"`
+
+	// Seed of the word generator used for the run, 0 if unknown, with the
+	// generator version and word distribution needed to replay it
+	Seed             int64  `json:"seed,omitempty"`
+	GeneratorVersion int    `json:"generator_version,omitempty"`
+	WordDist         string `json:"word_dist,omitempty"` // Empty for the uniform distribution
+
+	// Milliseconds between consecutive key presses; only sent with submissions
+	// so the server can spot implausible (e.g. pasted or scripted) input
+	Intervals []int `json:"intervals,omitempty"`
+
+	// Only sent with submissions: when the test was played, for scores sent
+	// late from the offline queue, and a key the server uses to store a score
+	// only once however many times it is sent
+	PlayedAt       *time.Time `json:"played_at,omitempty"`
+	IdempotencyKey string     `json:"idempotency_key,omitempty"`
+
+	// Set only in SubmitScore responses
+	PersonalBest bool    `json:"personal_best,omitempty"`
+	PreviousBest float64 `json:"previous_best,omitempty"`
 }
 
 // UserStats represents user statistics and ranking
@@ -38,6 +92,7 @@ type UserStats struct {
 	Rank            int     `json:"rank"`
 	TotalScores     int     `json:"total_scores"`
 	QualifiedScores int     `json:"qualified_scores"`
+	NextRankWPM     float64 `json:"next_rank_wpm,omitempty"` // Best WPM of the user ranked immediately above
 }
 
 // AuthUser represents authenticated user information
@@ -59,33 +114,122 @@ type AuthData struct {
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
-	token      string
+	token      atomic.Value // string; set by the auth manager while requests may be in flight
 }
 
 // NewClient creates a new API client
 func NewClient() *Client {
-	// Allow environment variable to override default URL
-	baseURL := os.Getenv("ZENTYPE_API_URL")
-	if baseURL == "" {
-		baseURL = DefaultBaseURL
-	}
-	
 	return &Client{
 		httpClient: &http.Client{
-			Timeout: Timeout,
+			Timeout:   Timeout,
+			Transport: versionTransport{next: loggingTransport{next: http.DefaultTransport}},
 		},
-		baseURL: baseURL,
+		baseURL: resolveBaseURL(),
+	}
+}
+
+// loggingTransport records the outcome of every API request in the debug log.
+// Only the method, path, status and timing are logged, never headers or bodies.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		logging.Printf("api: %s %s failed after %s: %v", req.Method, req.URL.Path, elapsed, err)
+		return nil, err
+	}
+	logging.Printf("api: %s %s -> %d in %s", req.Method, req.URL.Path, resp.StatusCode, elapsed)
+	return resp, nil
+}
+
+// versionTransport tells the server which API version the client speaks and
+// remembers the version the server answers with
+type versionTransport struct {
+	next http.RoundTripper
+}
+
+func (t versionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the caller's request
+	req = req.Clone(req.Context())
+	req.Header.Set(APIVersionHeader, strconv.Itoa(APIVersion))
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, err := strconv.Atoi(resp.Header.Get(APIVersionHeader)); err == nil {
+		serverAPIVersion.Store(int64(v))
+	} else if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		// Only a handled request proves the server predates the header: errors
+		// such as an unmatched route's 404, or a proxy's 502, never carry it
+		serverAPIVersion.Store(1)
+	}
+	return resp, nil
+}
+
+// VersionWarning describes a mismatch between the client and server API
+// versions seen during this run, or returns "" if they match or no request was made
+func VersionWarning() string {
+	server := int(serverAPIVersion.Load())
+	switch {
+	case server == 0 || server == APIVersion:
+		return ""
+	case server > APIVersion:
+		return "your zentype is outdated; some features may not work. Update with: go install github.com/nemaniabhiram/zentype.cli/zt@latest"
+	default:
+		return "the server is running an older API than this zentype; some features may not work"
+	}
+}
+
+// resolveBaseURL builds the API base URL from the environment.
+//
+// ZENTYPE_API_URL is a full base URL including the path prefix
+// (e.g. https://host/api) and takes precedence when set. Otherwise the base is
+// ZENTYPE_API_HOST (scheme and host, e.g. https://host) joined with
+// ZENTYPE_API_PREFIX (e.g. /custom, or empty to serve from the root).
+// Endpoints such as /leaderboard are appended to the result.
+func resolveBaseURL() string {
+	if baseURL := os.Getenv("ZENTYPE_API_URL"); baseURL != "" {
+		return strings.TrimRight(baseURL, "/")
+	}
+
+	host := os.Getenv("ZENTYPE_API_HOST")
+	if host == "" {
+		host = DefaultHost
+	}
+
+	prefix, ok := os.LookupEnv("ZENTYPE_API_PREFIX")
+	if !ok {
+		prefix = DefaultPrefix
+	}
+	prefix = strings.Trim(prefix, "/")
+
+	base := strings.TrimRight(host, "/")
+	if prefix != "" {
+		base += "/" + prefix
 	}
+	return base
 }
 
 // SetToken sets the authentication token
 func (c *Client) SetToken(token string) {
-	c.token = token
+	c.token.Store(token)
 }
 
 // GetToken returns the current authentication token
 func (c *Client) GetToken() string {
-	return c.token
+	token, _ := c.token.Load().(string)
+	return token
+}
+
+// SetTimeout limits how long each request may take, in place of Timeout
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.httpClient.Timeout = timeout
 }
 
 // makeAuthenticatedRequest makes an HTTP request with authentication
@@ -115,23 +259,59 @@ func (c *Client) makeAuthenticatedRequest(method, endpoint string, body interfac
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
+	if token := c.GetToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, c.describeRequestError(err)
 	}
 
 	return resp, nil
 }
 
+// describeRequestError turns a transport-level failure into an actionable message,
+// distinguishing timeouts, refused connections, DNS failures and TLS problems
+func (c *Client) describeRequestError(err error) error {
+	var dnsErr *net.DNSError
+	var certErr *tls.CertificateVerificationError
+	var unknownAuthErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var netErr net.Error
+
+	switch {
+	case errors.As(err, &dnsErr):
+		return fmt.Errorf("could not resolve %s (check your internet connection or ZENTYPE_API_URL): %w", dnsErr.Name, err)
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return fmt.Errorf("connection refused by %s (is the server running?): %w", c.baseURL, err)
+	case errors.As(err, &certErr), errors.As(err, &unknownAuthErr), errors.As(err, &hostnameErr):
+		return fmt.Errorf("TLS certificate error talking to %s (check the URL or your system clock): %w", c.baseURL, err)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return fmt.Errorf("request timed out after %s (the server may be slow or unreachable): %w", c.httpClient.Timeout, err)
+	default:
+		return fmt.Errorf("request failed: %w", err)
+	}
+}
+
+// IsUnreachable reports whether err means the request never reached the
+// server: the host couldn't be resolved or no connection could be made. Such
+// requests are safe to retry later. Other failures, such as a timeout while
+// waiting for the answer, may have reached the server and are not included.
+func IsUnreachable(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
 // CheckHealth verifies the API server is running
 func (c *Client) CheckHealth() error {
 	resp, err := c.httpClient.Get(c.baseURL + "/health")
 	if err != nil {
-		return fmt.Errorf("failed to connect to API: %w", err)
+		return fmt.Errorf("failed to connect to API: %w", c.describeRequestError(err))
 	}
 	defer resp.Body.Close()
 
@@ -152,7 +332,7 @@ func (c *Client) GetAuthURL() (*AuthData, error) {
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get auth URL: %w", err)
+		return nil, fmt.Errorf("failed to get auth URL: %w", c.describeRequestError(err))
 	}
 	defer resp.Body.Close()
 
@@ -170,7 +350,7 @@ func (c *Client) GetAuthURL() (*AuthData, error) {
 
 // VerifyToken verifies the authentication token and returns user info
 func (c *Client) VerifyToken() (*AuthUser, error) {
-	if c.token == "" {
+	if c.GetToken() == "" {
 		return nil, fmt.Errorf("no authentication token set")
 	}
 
@@ -197,18 +377,78 @@ func (c *Client) VerifyToken() (*AuthUser, error) {
 }
 
 // SubmitScore submits a typing test score to the leaderboard
-func (c *Client) SubmitScore(stats game.TypingStats, duration int, language string) (*LeaderboardEntry, error) {
-	if c.token == "" {
+func (c *Client) SubmitScore(stats game.TypingStats, duration int, language string, details ScoreDetails) (*LeaderboardEntry, error) {
+	if c.GetToken() == "" {
 		return nil, fmt.Errorf("authentication required to submit scores")
 	}
 
-	entry := LeaderboardEntry{
-		WPM:      stats.WPM,
-		Accuracy: stats.Accuracy,
-		Duration: duration,
-		Language: language,
+	return c.postScore(LeaderboardEntry{
+		WPM:       stats.WPM,
+		Accuracy:  stats.Accuracy,
+		Duration:  duration,
+		Language:  language,
+		Seed:      details.Seed,
+		GeneratorVersion: details.GeneratorVersion,
+		WordDist:  details.WordDist,
+		Intervals: details.Intervals,
+		PlayedAt:       details.playedAt(),
+		IdempotencyKey: details.IdempotencyKey,
+	})
+}
+
+// ScoreDetails carries optional information about how a score was typed
+type ScoreDetails struct {
+	Seed             int64  // Word generator seed, 0 if none
+	GeneratorVersion int    // game.GeneratorVersion the seed was played with
+	WordDist         string // Word distribution the seed was played with; empty for uniform
+	Intervals        []int  // Milliseconds between consecutive key presses
+	PlayedAt         time.Time // When the test was played; zero for now
+	IdempotencyKey   string    // From NewIdempotencyKey; the same for every attempt to send one score
+}
+
+// playedAt returns PlayedAt for a request, nil if it isn't set
+func (d ScoreDetails) playedAt() *time.Time {
+	if d.PlayedAt.IsZero() {
+		return nil
 	}
+	playedAt := d.PlayedAt.UTC()
+	return &playedAt
+}
 
+// NewIdempotencyKey returns a random key identifying one score across retries
+func NewIdempotencyKey() string {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return "" // The score is still sent, just without duplicate protection
+	}
+	return hex.EncodeToString(key)
+}
+
+// SubmitDailyScore submits a 60-second daily challenge result to the board for
+// challengeDate (YYYY-MM-DD, UTC). The returned rank is the rank on that board.
+func (c *Client) SubmitDailyScore(stats game.TypingStats, challengeDate string, details ScoreDetails) (*LeaderboardEntry, error) {
+	if c.GetToken() == "" {
+		return nil, fmt.Errorf("authentication required to submit scores")
+	}
+
+	return c.postScore(LeaderboardEntry{
+		WPM:           stats.WPM,
+		Accuracy:      stats.Accuracy,
+		Duration:      60,
+		Language:      "english",
+		Mode:          "daily",
+		ChallengeDate: challengeDate,
+		Seed:          details.Seed,
+		GeneratorVersion: details.GeneratorVersion,
+		WordDist:      details.WordDist,
+		Intervals:     details.Intervals,
+		PlayedAt:       details.playedAt(),
+		IdempotencyKey: details.IdempotencyKey,
+	})
+}
+
+// postScore sends a score to the server and decodes the stored entry
+func (c *Client) postScore(entry LeaderboardEntry) (*LeaderboardEntry, error) {
 	resp, err := c.makeAuthenticatedRequest("POST", "/scores", entry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to submit score: %w", err)
@@ -243,21 +483,28 @@ type LeaderboardResponse struct {
 	UserEntry *LeaderboardEntry  `json:"user_entry,omitempty"`
 }
 
-// GetLeaderboard fetches the top 10 leaderboard entries and user's entry if not in top 10
-func (c *Client) GetLeaderboard(language string) (*LeaderboardResponse, error) {
+// GetLeaderboard fetches the top leaderboard entries and user's entry if not among them.
+// The server clamps limit to its own maximum; a limit <= 0 uses the default of 10.
+func (c *Client) GetLeaderboard(language string, limit int) (*LeaderboardResponse, error) {
 	if language == "" {
 		language = "english"
 	}
+	if limit <= 0 {
+		limit = DefaultLeaderboardLimit
+	}
 
-	url := fmt.Sprintf("%s/leaderboard?language=%s", c.baseURL, language)
+	endpoint := fmt.Sprintf("/leaderboard?language=%s&limit=%d", url.QueryEscape(language), limit)
 	
 	// Use authenticated request if token is available
 	var resp *http.Response
 	var err error
-	if c.token != "" {
-		resp, err = c.makeAuthenticatedRequest("GET", "/leaderboard?language="+language, nil)
+	if c.GetToken() != "" {
+		resp, err = c.makeAuthenticatedRequest("GET", endpoint, nil)
 	} else {
-		resp, err = c.httpClient.Get(url)
+		resp, err = c.httpClient.Get(c.baseURL + endpoint)
+		if err != nil {
+			err = c.describeRequestError(err)
+		}
 	}
 	
 	if err != nil {
@@ -277,9 +524,80 @@ func (c *Client) GetLeaderboard(language string) (*LeaderboardResponse, error) {
 	return &response, nil
 }
 
+// ActiveEntry ranks a user by how often they play rather than how fast
+type ActiveEntry struct {
+	Username        string `json:"username"`
+	GitHubID        int    `json:"github_id"`
+	GitHubLogin     string `json:"github_login,omitempty"`
+	QualifiedScores int    `json:"qualified_scores"`
+	LongestStreak   int    `json:"longest_streak"` // Most consecutive days with a qualifying score
+	Rank            int    `json:"rank"`
+}
+
+// GetActiveLeaderboard fetches the players with the most qualifying tests
+func (c *Client) GetActiveLeaderboard(language string, limit int) ([]ActiveEntry, error) {
+	if language == "" {
+		language = "english"
+	}
+	if limit <= 0 {
+		limit = DefaultLeaderboardLimit
+	}
+
+	resp, err := c.httpClient.Get(fmt.Sprintf("%s/leaderboard/active?language=%s&limit=%d", c.baseURL, url.QueryEscape(language), limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch active leaderboard: %w", c.describeRequestError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status: %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Entries []ActiveEntry `json:"entries"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode active leaderboard: %w", err)
+	}
+
+	return response.Entries, nil
+}
+
+// DailyLeaderboardResponse represents the daily challenge board for one date
+type DailyLeaderboardResponse struct {
+	Date    string             `json:"date"`
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+// GetDailyLeaderboard fetches the daily challenge board for date (YYYY-MM-DD);
+// an empty date means today in UTC
+func (c *Client) GetDailyLeaderboard(date string) (*DailyLeaderboardResponse, error) {
+	endpoint := fmt.Sprintf("%s/leaderboard/daily?limit=%d", c.baseURL, DefaultLeaderboardLimit)
+	if date != "" {
+		endpoint += "&date=" + url.QueryEscape(date)
+	}
+
+	resp, err := c.httpClient.Get(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch daily leaderboard: %w", c.describeRequestError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status: %d", resp.StatusCode)
+	}
+
+	var response DailyLeaderboardResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode daily leaderboard: %w", err)
+	}
+
+	return &response, nil
+}
+
 // GetUserRank gets the current user's ranking and statistics
 func (c *Client) GetUserRank(language string) (*UserStats, error) {
-	if c.token == "" {
+	if c.GetToken() == "" {
 		return nil, fmt.Errorf("authentication required to get user rank")
 	}
 
@@ -287,8 +605,7 @@ func (c *Client) GetUserRank(language string) (*UserStats, error) {
 		language = "english"
 	}
 
-	url := fmt.Sprintf("/user/rank?language=%s", language)
-	resp, err := c.makeAuthenticatedRequest("GET", url, nil)
+	resp, err := c.makeAuthenticatedRequest("GET", "/user/rank?language="+url.QueryEscape(language), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user rank: %w", err)
 	}
@@ -310,9 +627,335 @@ func (c *Client) GetUserRank(language string) (*UserStats, error) {
 	return &stats, nil
 }
 
+// RankHistoryPoint is the user's best WPM and rank at the end of one week
+type RankHistoryPoint struct {
+	WeekStart time.Time `json:"week_start"`
+	BestWPM   float64   `json:"best_wpm"`
+	Rank      int       `json:"rank"`
+}
+
+// GetRankHistory fetches the authenticated user's weekly best WPM and rank, oldest first
+func (c *Client) GetRankHistory(language string) ([]RankHistoryPoint, error) {
+	if c.GetToken() == "" {
+		return nil, fmt.Errorf("authentication required to get rank history")
+	}
+
+	if language == "" {
+		language = "english"
+	}
+
+	resp, err := c.makeAuthenticatedRequest("GET", "/user/rank-history?language="+url.QueryEscape(language), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rank history: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("authentication required")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status: %d", resp.StatusCode)
+	}
+
+	var points []RankHistoryPoint
+	if err := json.NewDecoder(resp.Body).Decode(&points); err != nil {
+		return nil, fmt.Errorf("failed to decode rank history: %w", err)
+	}
+
+	return points, nil
+}
+
+// UserSummary compares the user's qualifying scores this week with last week
+type UserSummary struct {
+	QualifiedThisWeek int     `json:"qualified_this_week"`
+	QualifiedLastWeek int     `json:"qualified_last_week"`
+	BestWPMThisWeek   float64 `json:"best_wpm_this_week"`
+	BestWPMAllTime    float64 `json:"best_wpm_all_time"`
+}
+
+// GetUserSummary fetches the authenticated user's activity this week against last week
+func (c *Client) GetUserSummary(language string) (*UserSummary, error) {
+	if c.GetToken() == "" {
+		return nil, fmt.Errorf("authentication required to get user summary")
+	}
+
+	if language == "" {
+		language = "english"
+	}
+
+	resp, err := c.makeAuthenticatedRequest("GET", "/user/summary?language="+url.QueryEscape(language), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user summary: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("authentication required")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status: %d", resp.StatusCode)
+	}
+
+	var summary UserSummary
+	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+		return nil, fmt.Errorf("failed to decode user summary: %w", err)
+	}
+
+	return &summary, nil
+}
+
+// GetPublicProfile fetches another user's public statistics by GitHub login
+func (c *Client) GetPublicProfile(login, language string) (*UserStats, error) {
+	if language == "" {
+		language = "english"
+	}
+
+	resp, err := c.httpClient.Get(fmt.Sprintf("%s/users/%s?language=%s", c.baseURL, url.PathEscape(login), url.QueryEscape(language)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", c.describeRequestError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("user %q not found or profile is private", login)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status: %d", resp.StatusCode)
+	}
+
+	var stats UserStats
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, fmt.Errorf("failed to decode user stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// GetScore fetches a single score by ID, e.g. to verify a shared result
+func (c *Client) GetScore(id int) (*LeaderboardEntry, error) {
+	resp, err := c.httpClient.Get(fmt.Sprintf("%s/scores/%d", c.baseURL, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score: %w", c.describeRequestError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("score %d not found", id)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status: %d", resp.StatusCode)
+	}
+
+	var entry LeaderboardEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entry); err != nil {
+		return nil, fmt.Errorf("failed to decode score: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// SetVisibility controls whether the user's scores appear on the public leaderboard
+func (c *Client) SetVisibility(public bool) error {
+	if c.GetToken() == "" {
+		return fmt.Errorf("authentication required to change visibility")
+	}
+
+	resp, err := c.makeAuthenticatedRequest("POST", "/user/visibility", map[string]bool{"public": public})
+	if err != nil {
+		return fmt.Errorf("failed to update visibility: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("authentication required")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server returned status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// SetDisplayName sets the name shown for the user on the leaderboard and
+// returns the name the server stored. An empty name goes back to the GitHub login.
+func (c *Client) SetDisplayName(name string) (string, error) {
+	if c.GetToken() == "" {
+		return "", fmt.Errorf("authentication required to change your name")
+	}
+
+	resp, err := c.makeAuthenticatedRequest("POST", "/user/name", map[string]string{"username": name})
+	if err != nil {
+		return "", fmt.Errorf("failed to update name: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return "", fmt.Errorf("authentication required")
+	}
+
+	if resp.StatusCode == http.StatusBadRequest {
+		// The server explains what is wrong with the name in plain text
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("%s", strings.TrimSpace(string(message)))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("server returned status: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Username string `json:"username"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.Username, nil
+}
+
+// WPMBucket is one bar of the WPM distribution histogram
+type WPMBucket struct {
+	MinWPM int `json:"min_wpm"`
+	MaxWPM int `json:"max_wpm"`
+	Count  int `json:"count"`
+}
+
+// WPMDistribution is the histogram of best qualifying WPM per user
+type WPMDistribution struct {
+	Language   string      `json:"language"`
+	BucketSize int         `json:"bucket_size"`
+	TotalUsers int         `json:"total_users"`
+	Buckets    []WPMBucket `json:"buckets"`
+}
+
+// GetDistribution fetches how players' best WPM scores are distributed
+func (c *Client) GetDistribution(language string) (*WPMDistribution, error) {
+	if language == "" {
+		language = "english"
+	}
+
+	resp, err := c.httpClient.Get(fmt.Sprintf("%s/stats/distribution?language=%s", c.baseURL, url.QueryEscape(language)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch distribution: %w", c.describeRequestError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status: %d", resp.StatusCode)
+	}
+
+	var distribution WPMDistribution
+	if err := json.NewDecoder(resp.Body).Decode(&distribution); err != nil {
+		return nil, fmt.Errorf("failed to decode distribution: %w", err)
+	}
+
+	return &distribution, nil
+}
+
+// GlobalStats summarizes qualifying scores across all players
+type GlobalStats struct {
+	TotalUsers      int     `json:"total_users"`
+	TotalScores     int     `json:"total_scores"`
+	QualifiedScores int     `json:"qualified_scores"`
+	HighestWPM      float64 `json:"highest_wpm"`
+	AverageWPM      float64 `json:"average_wpm"`
+	AverageAccuracy float64 `json:"average_accuracy"`
+	TopUser         string  `json:"top_user"`
+}
+
+// GetGlobalStats fetches totals and averages over every qualifying score
+func (c *Client) GetGlobalStats() (*GlobalStats, error) {
+	resp, err := c.httpClient.Get(c.baseURL + "/stats")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch global stats: %w", c.describeRequestError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status: %d", resp.StatusCode)
+	}
+
+	var stats GlobalStats
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, fmt.Errorf("failed to decode global stats: %w", err)
+	}
+
+	return &stats, nil
+}
+
+// LanguageStats summarizes qualifying scores in one language
+type LanguageStats struct {
+	Language        string  `json:"language"`
+	Users           int     `json:"users"`
+	Scores          int     `json:"scores"`
+	AverageWPM      float64 `json:"average_wpm"`
+	AverageAccuracy float64 `json:"average_accuracy"`
+	BestWPM         float64 `json:"best_wpm"`
+}
+
+// GetLanguageStats fetches per-language averages, most players first
+func (c *Client) GetLanguageStats() ([]LanguageStats, error) {
+	resp, err := c.httpClient.Get(c.baseURL + "/stats/languages")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch language stats: %w", c.describeRequestError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status: %d", resp.StatusCode)
+	}
+
+	var languages []LanguageStats
+	if err := json.NewDecoder(resp.Body).Decode(&languages); err != nil {
+		return nil, fmt.Errorf("failed to decode language stats: %w", err)
+	}
+
+	return languages, nil
+}
+
+// ActivityEntry is one recent qualifying submission in the activity feed
+type ActivityEntry struct {
+	Username    string    `json:"username"`
+	GitHubLogin string    `json:"github_login,omitempty"`
+	WPM         float64   `json:"wpm"`
+	Accuracy    float64   `json:"accuracy"`
+	Language    string    `json:"language"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// GetActivity fetches the most recent qualifying submissions across all players
+func (c *Client) GetActivity(limit int) ([]ActivityEntry, error) {
+	if limit <= 0 {
+		limit = DefaultLeaderboardLimit
+	}
+
+	resp, err := c.httpClient.Get(fmt.Sprintf("%s/activity?limit=%d", c.baseURL, limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch activity: %w", c.describeRequestError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status: %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Entries []ActivityEntry `json:"entries"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode activity: %w", err)
+	}
+
+	return response.Entries, nil
+}
+
 // IsAuthenticated checks if the client has a valid token
 func (c *Client) IsAuthenticated() bool {
-	if c.token == "" {
+	if c.GetToken() == "" {
 		return false
 	}
 
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 	"github.com/nemaniabhiram/zentype.cli/internal/api"
+	"github.com/nemaniabhiram/zentype.cli/internal/logging"
 )
 
 // Session represents a user authentication session
@@ -20,29 +22,29 @@ type Session struct {
 	CreatedAt   time.Time `json:"created_at"`
 }
 
-// Manager handles user authentication and session management
+// Manager handles user authentication and session management. It is safe
+// for concurrent use, e.g. by the UI rendering while a score is submitted.
 type Manager struct {
 	client      *api.Client
+	mu          sync.RWMutex // Guards session and configPath
 	session     *Session
-	configPath  string
+	configPath  string // Empty when sessions can't be persisted and live in memory only
 }
 
-// NewManager creates a new authentication manager
-func NewManager(client *api.Client) (*Manager, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get home directory: %w", err)
-	}
+// memoryOnlyWarning makes sure the user is told about memory-only mode once per run
+var memoryOnlyWarning sync.Once
 
-	configDir := filepath.Join(homeDir, ".zentype")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create config directory: %w", err)
-	}
+// NewManager creates a new authentication manager. If the config directory
+// can't be created (e.g. a read-only home), sessions are kept in memory only.
+func NewManager(client *api.Client) (*Manager, error) {
+	manager := &Manager{client: client}
 
-	manager := &Manager{
-		client:     client,
-		configPath: filepath.Join(configDir, "auth.json"),
+	configDir, err := sessionDir()
+	if err != nil {
+		manager.useMemoryOnly(err)
+		return manager, nil
 	}
+	manager.configPath = filepath.Join(configDir, "auth.json")
 
 	// Try to load existing session
 	if err := manager.loadSession(); err == nil {
@@ -61,17 +63,46 @@ func NewManager(client *api.Client) (*Manager, error) {
 	return manager, nil
 }
 
+// sessionDir returns ~/.zentype, creating it if needed
+func sessionDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	configDir := filepath.Join(homeDir, ".zentype")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+	return configDir, nil
+}
+
+// useMemoryOnly stops persisting sessions after err and warns the user once.
+// Like the other unexported methods, it expects the caller to hold m.mu.
+func (m *Manager) useMemoryOnly(err error) {
+	m.configPath = ""
+	logging.Printf("auth: sessions kept in memory only: %v", err)
+	memoryOnlyWarning.Do(func() {
+		fmt.Fprintf(os.Stderr, "Warning: %v; sign-ins won't be saved\n", err)
+	})
+}
+
 // IsAuthenticated checks if the user is authenticated
 func (m *Manager) IsAuthenticated() bool {
-	return m.session != nil && m.isSessionValid()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.isSessionValid()
 }
 
-// GetUser returns the current authenticated user info
+// GetUser returns a copy of the current authenticated user info
 func (m *Manager) GetUser() *Session {
-	if !m.IsAuthenticated() {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.isSessionValid() {
 		return nil
 	}
-	return m.session
+	session := *m.session
+	return &session
 }
 
 // SetToken manually sets an authentication token (from OAuth flow)
@@ -82,6 +113,7 @@ func (m *Manager) SetToken(token string) error {
 	user, err := m.client.VerifyToken()
 	if err != nil {
 		m.client.SetToken("") // Clear invalid token
+		logging.Printf("auth: token verification failed: %v", err)
 		return fmt.Errorf("invalid token: %w", err)
 	}
 
@@ -96,7 +128,10 @@ func (m *Manager) SetToken(token string) error {
 		CreatedAt:   time.Now(),
 	}
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.session = session
+	logging.Printf("auth: signed in as %s", user.Username)
 	return m.saveSession()
 }
 
@@ -111,6 +146,9 @@ func (m *Manager) GetAuthURL() (string, error) {
 
 // Logout clears the current session
 func (m *Manager) Logout() error {
+	logging.Printf("auth: logged out")
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.session = nil
 	m.client.SetToken("")
 	return m.clearSession()
@@ -118,6 +156,10 @@ func (m *Manager) Logout() error {
 
 // loadSession loads the session from disk
 func (m *Manager) loadSession() error {
+	if m.configPath == "" {
+		return fmt.Errorf("no session file")
+	}
+
 	data, err := os.ReadFile(m.configPath)
 	if err != nil {
 		return err
@@ -138,17 +180,27 @@ func (m *Manager) saveSession() error {
 		return fmt.Errorf("no session to save")
 	}
 
+	if m.configPath == "" {
+		return nil // Memory-only: the session lasts until the program exits
+	}
+
 	data, err := json.MarshalIndent(m.session, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(m.configPath, data, 0600)
+	if err := os.WriteFile(m.configPath, data, 0600); err != nil {
+		m.useMemoryOnly(fmt.Errorf("failed to save session: %w", err))
+	}
+	return nil
 }
 
 // clearSession removes the session file
 func (m *Manager) clearSession() error {
 	m.session = nil
+	if m.configPath == "" {
+		return nil
+	}
 	if _, err := os.Stat(m.configPath); os.IsNotExist(err) {
 		return nil // File doesn't exist, nothing to clear
 	}
@@ -183,6 +235,12 @@ func (m *Manager) RefreshUserInfo() error {
 		return fmt.Errorf("failed to refresh user info: %w", err)
 	}
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.session == nil {
+		return fmt.Errorf("not authenticated") // Logged out while verifying
+	}
+
 	// Update session with fresh data
 	m.session.Username = user.Username
 	m.session.GitHubLogin = user.Login
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/api"
+)
+
+// verifyServer answers /auth/verify for any token "token-N" as user N
+func verifyServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		var id int
+		if r.URL.Path != "/auth/verify" || !strings.HasPrefix(token, "token-") {
+			http.Error(w, "Invalid token", http.StatusUnauthorized)
+			return
+		}
+		fmt.Sscanf(token, "token-%d", &id)
+		json.NewEncoder(w).Encode(api.AuthUser{
+			ID:       id,
+			Username: fmt.Sprintf("user%d", id),
+			GitHubID: id,
+			Login:    fmt.Sprintf("user%d", id),
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestManagerConcurrentAccess is meant for go test -race: the UI checks the
+// session on every render while a sign-in or score submission runs alongside
+func TestManagerConcurrentAccess(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("ZENTYPE_API_URL", verifyServer(t).URL)
+
+	manager, err := NewManager(api.NewClient())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if err := manager.SetToken(fmt.Sprintf("token-%d", i*100+j)); err != nil {
+					t.Errorf("SetToken: %v", err)
+					return
+				}
+				manager.RefreshUserInfo()
+			}
+		}(i)
+	}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				if manager.IsAuthenticated() {
+					if user := manager.GetUser(); user != nil && user.Username == "" {
+						t.Error("GetUser returned a session without a username")
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !manager.IsAuthenticated() {
+		t.Fatal("not authenticated after signing in")
+	}
+
+	// The last session saved is the one a new run loads
+	user := manager.GetUser()
+	reloaded, err := NewManager(api.NewClient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.GetUser(); got == nil || got.Token != user.Token {
+		t.Errorf("reloaded session %+v, want token %q", got, user.Token)
+	}
+}
+
+func TestSetTokenRejected(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("ZENTYPE_API_URL", verifyServer(t).URL)
+
+	client := api.NewClient()
+	manager, err := NewManager(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.SetToken("forged"); err == nil {
+		t.Error("SetToken accepted a token the server rejected")
+	}
+	if manager.IsAuthenticated() || client.GetToken() != "" {
+		t.Error("a rejected token was kept")
+	}
+}
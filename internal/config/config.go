@@ -0,0 +1,189 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Config holds user preferences stored in ~/.zentype/config.json
+type Config struct {
+	// SubmitScores controls whether eligible results are sent to the leaderboard
+	SubmitScores bool `json:"submit_scores"`
+
+	// SubmitOnlyPB skips submission unless the run beats the user's current best
+	SubmitOnlyPB bool `json:"submit_only_pb"`
+
+	// Personal goals shown on the results screen; 0 means no goal
+	GoalWPM      float64 `json:"goal_wpm,omitempty"`
+	GoalAccuracy float64 `json:"goal_accuracy,omitempty"`
+
+	// RestartMode is what Enter on the results screen does: RestartNew or RestartSame
+	RestartMode string `json:"restart_mode"`
+
+	// Defaults for the typing test; the matching command-line flags override them
+	Duration       int    `json:"duration"`        // Test length in seconds
+	Language       string `json:"language"`        // Word list language
+	WordDist       string `json:"word_dist"`       // Word sampling: uniform or frequency
+	Blink          bool   `json:"blink"`           // Blink the caret
+	Focus          bool   `json:"focus"`           // Dim everything except the current word
+	Minimal        bool   `json:"minimal"`         // Hide the timer while typing
+	WordsLeft      bool   `json:"words_left"`      // Show an estimate of the words left next to the timer
+	CompareAverage bool   `json:"compare_average"` // Compare submitted results with the global averages
+	LiveWPM        bool   `json:"live_wpm"`        // Show a live WPM counter next to the timer
+	RecentWord     bool   `json:"recent_word"`     // Style the word just typed apart from older typed words
+	Theme          string `json:"theme,omitempty"` // Color theme; empty is the default theme
+
+	// Decimals is how many decimal places WPM, accuracy and time are shown
+	// with on the results screen and leaderboard (0 to MaxDecimals)
+	Decimals int `json:"decimals,omitempty"`
+
+	// WPMSmoothing is the share of the live WPM counter's previous value kept
+	// after one second (0 to MaxWPMSmoothing); 0 shows the raw speed
+	WPMSmoothing float64 `json:"wpm_smoothing"`
+
+	// RefreshMS is the shortest interval between redraws of the timer, ghost
+	// and blinking caret, to save power; 0 uses their normal rates
+	RefreshMS int `json:"refresh_ms,omitempty"`
+
+	// HiddenUsers lists GitHub logins left out of your leaderboard view
+	HiddenUsers []string `json:"hidden_users,omitempty"`
+
+	// Playlists are named sequences of tests run with 'zt playlist <name>'
+	Playlists map[string][]PlaylistStep `json:"playlists,omitempty"`
+
+	// TutorialDone is set once 'zt tutorial' has been completed or declined
+	TutorialDone bool `json:"tutorial_done,omitempty"`
+
+	// Keys moves control actions (ActionQuit, ActionRestart, ...) to other
+	// keys, written as Bubble Tea key names such as "ctrl+r" or "f2"
+	Keys map[string]string `json:"keys,omitempty"`
+
+	keysErr error // Why Keys was ignored on load
+}
+
+// Restart modes for the results screen
+const (
+	RestartNew  = "new"  // Start the next test with new words
+	RestartSame = "same" // Retype the words of the test just finished
+)
+
+// MaxRefreshMS caps refresh_ms. It is below the shortest test (10 s), so a
+// timer tick scheduled before the test starts still lands before it ends.
+const MaxRefreshMS = 5000
+
+// MaxDecimals caps decimals; stats aren't measured more precisely than this
+const MaxDecimals = 2
+
+// Live WPM smoothing: the default and the cap, above which the counter would
+// barely move
+const (
+	DefaultWPMSmoothing = 0.6
+	MaxWPMSmoothing     = 0.95
+)
+
+// ValidRestartMode reports whether mode is a known restart mode
+func ValidRestartMode(mode string) bool {
+	return mode == RestartNew || mode == RestartSame
+}
+
+// HasGoals reports whether any personal goal is set
+func (c *Config) HasGoals() bool {
+	return c.GoalWPM > 0 || c.GoalAccuracy > 0
+}
+
+// GoalsMet reports whether wpm and accuracy meet every goal that is set
+func (c *Config) GoalsMet(wpm, accuracy float64) bool {
+	return c.HasGoals() &&
+		(c.GoalWPM <= 0 || wpm >= c.GoalWPM) &&
+		(c.GoalAccuracy <= 0 || accuracy >= c.GoalAccuracy)
+}
+
+// IsHidden reports whether login is in HiddenUsers, ignoring case
+func (c *Config) IsHidden(login string) bool {
+	for _, hidden := range c.HiddenUsers {
+		if strings.EqualFold(hidden, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// Default returns the configuration used when no config file exists
+func Default() *Config {
+	return &Config{
+		SubmitScores: true,
+		RestartMode:  RestartNew,
+		Duration:     60,
+		Language:     "english",
+		WordDist:     "uniform",
+		WPMSmoothing: DefaultWPMSmoothing,
+	}
+}
+
+// Path returns the location of the config file
+func Path() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".zentype", "config.json"), nil
+}
+
+// Load reads the config file, falling back to defaults for missing keys.
+// A missing file is not an error.
+func Load() (*Config, error) {
+	cfg := Default()
+
+	path, err := Path()
+	if err != nil {
+		return cfg, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return Default(), fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	if !ValidRestartMode(cfg.RestartMode) {
+		cfg.RestartMode = RestartNew
+	}
+	cfg.RefreshMS = max(0, min(cfg.RefreshMS, MaxRefreshMS))
+	cfg.WPMSmoothing = max(0, min(cfg.WPMSmoothing, MaxWPMSmoothing))
+	cfg.Decimals = max(0, min(cfg.Decimals, MaxDecimals))
+	// Bad bindings fall back to the defaults rather than leaving an action
+	// unreachable; they stay in Keys so saving the config doesn't lose them
+	if err := ValidateKeys(cfg.Keys); err != nil {
+		cfg.keysErr = fmt.Errorf("custom keys ignored: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Save writes the config file
+func (c *Config) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
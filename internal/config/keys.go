@@ -0,0 +1,127 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Control actions that can be moved to other keys with the "keys" section.
+// Ctrl+C always quits as well, whatever quit is bound to.
+const (
+	ActionQuit     = "quit"      // Leave the test, leaderboard or feed
+	ActionRestart  = "restart"   // Restart the test, or start the next one from results
+	ActionNewWords = "new-words" // Start a test with new words, even in restart_mode same
+	ActionFinish   = "finish"    // End an open-ended test
+	ActionRefresh  = "refresh"   // Reload the leaderboard or feed
+)
+
+// DefaultKeys are the keys bound to each action when "keys" doesn't override them
+var DefaultKeys = map[string]string{
+	ActionQuit:     "esc",
+	ActionRestart:  "enter",
+	ActionNewWords: "ctrl+n",
+	ActionFinish:   "ctrl+d",
+	ActionRefresh:  "r",
+}
+
+// testActions are handled while typing, where printable keys must stay typeable
+var testActions = []string{ActionQuit, ActionRestart, ActionNewWords, ActionFinish}
+
+// reservedKeys have fixed meanings that can't be given to an action
+var reservedKeys = []string{"ctrl+c", "backspace", "tab", "shift+tab", "left", "right", "f5"}
+
+// Key returns the key bound to action
+func (c *Config) Key(action string) string {
+	if key := c.Keys[action]; key != "" && c.keysErr == nil {
+		return key
+	}
+	return DefaultKeys[action]
+}
+
+// Action returns the action bound to key, or "" if none is
+func (c *Config) Action(key string) string {
+	for action := range DefaultKeys {
+		if c.Key(action) == key {
+			return action
+		}
+	}
+	return ""
+}
+
+// KeysError reports why the "keys" section was ignored when the config was
+// loaded, or nil if it was used
+func (c *Config) KeysError() error {
+	return c.keysErr
+}
+
+// ValidateKeys checks custom key bindings: every action must be known, keys
+// with a fixed meaning can't be used, keys pressed while typing can't be
+// printable characters, and no two actions may share a key
+func ValidateKeys(keys map[string]string) error {
+	actions := make([]string, 0, len(keys))
+	for action := range keys {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions) // Report problems in a stable order
+
+	for _, action := range actions {
+		key := keys[action]
+		if _, ok := DefaultKeys[action]; !ok {
+			return fmt.Errorf("unknown action %q", action)
+		}
+		for _, reserved := range reservedKeys {
+			if key == reserved {
+				return fmt.Errorf("%s can't use %q, which has a fixed meaning", action, key)
+			}
+		}
+		if isTestAction(action) && (key == " " || key == "space" || utf8.RuneCountInString(key) == 1) {
+			return fmt.Errorf("%s can't use %q, which is needed for typing", action, key)
+		}
+	}
+
+	bound := make(map[string]string)
+	c := &Config{Keys: keys}
+	for _, action := range sortedActions() {
+		key := c.Key(action)
+		if other, ok := bound[key]; ok {
+			return fmt.Errorf("%s and %s are both bound to %q", other, action, key)
+		}
+		bound[key] = action
+	}
+	return nil
+}
+
+// isTestAction reports whether action is handled during a typing test
+func isTestAction(action string) bool {
+	for _, a := range testActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedActions lists every action in a stable order
+func sortedActions() []string {
+	actions := make([]string, 0, len(DefaultKeys))
+	for action := range DefaultKeys {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	return actions
+}
+
+// KeyLabel formats a key for on-screen instructions, e.g. "Enter" or "Ctrl+N"
+func KeyLabel(key string) string {
+	parts := strings.Split(key, "+")
+	for i, part := range parts {
+		if utf8.RuneCountInString(part) > 1 {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		} else if i > 0 {
+			parts[i] = strings.ToUpper(part)
+		}
+	}
+	return strings.Join(parts, "+")
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PlaylistStep is one test in a practice playlist. A step types the language
+// word list unless Chars (like 'zt drill --chars') or Ngrams picks another
+// source.
+type PlaylistStep struct {
+	Label    string `json:"label,omitempty"`     // Shown in the summary; defaults to a description of the step
+	Duration int    `json:"duration"`            // Seconds, 10-300
+	Chars    string `json:"chars,omitempty"`     // Drill pseudo-words made of these characters
+	Ngrams   bool   `json:"ngrams,omitempty"`    // Drill common bigrams and trigrams
+	WordDist string `json:"word_dist,omitempty"` // Word sampling for word list steps
+	Submit   bool   `json:"submit,omitempty"`    // Submit an eligible word list result to the leaderboard
+}
+
+// Validate reports the first problem with the step's settings
+func (s PlaylistStep) Validate() error {
+	if s.Duration < 10 || s.Duration > 300 {
+		return fmt.Errorf("duration must be between 10 and 300 seconds")
+	}
+	if s.Chars != "" && s.Ngrams {
+		return fmt.Errorf("chars and ngrams cannot be used together")
+	}
+	for _, r := range s.Chars {
+		if r != ' ' && (r < 33 || r > 126) {
+			return fmt.Errorf("unsupported character %q in chars: only printable ASCII can be drilled", r)
+		}
+	}
+	if s.WordDist != "" && s.WordDist != "uniform" && s.WordDist != "frequency" {
+		return fmt.Errorf("invalid word_dist %q: use uniform or frequency", s.WordDist)
+	}
+	if s.Submit && (s.Chars != "" || s.Ngrams) {
+		return fmt.Errorf("only word list steps can be submitted")
+	}
+	return nil
+}
+
+// Describe returns the step's label, or a short description of it
+func (s PlaylistStep) Describe() string {
+	if s.Label != "" {
+		return s.Label
+	}
+	switch {
+	case s.Chars != "":
+		return fmt.Sprintf("%ds drill %q", s.Duration, s.Chars)
+	case s.Ngrams:
+		return fmt.Sprintf("%ds n-grams", s.Duration)
+	default:
+		return fmt.Sprintf("%ds words", s.Duration)
+	}
+}
+
+// Playlist returns the steps of the named playlist, checking every step
+func (c *Config) Playlist(name string) ([]PlaylistStep, error) {
+	steps, ok := c.Playlists[name]
+	if !ok {
+		return nil, fmt.Errorf("no playlist named %q in the config", name)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("playlist %q has no steps", name)
+	}
+	for i, step := range steps {
+		if err := step.Validate(); err != nil {
+			return nil, fmt.Errorf("playlist %q step %d: %w", name, i+1, err)
+		}
+	}
+	return steps, nil
+}
+
+// PlaylistNames returns the names of the configured playlists, sorted
+func (c *Config) PlaylistNames() []string {
+	names := make([]string, 0, len(c.Playlists))
+	for name := range c.Playlists {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,123 @@
+package game
+
+import "strings"
+
+// DiffKind classifies one step of an alignment between the text and what was typed
+type DiffKind int
+
+const (
+	DiffMatch      DiffKind = iota // Typed the expected character
+	DiffSubstitute                 // Typed a different character in its place
+	DiffMissing                    // Skipped an expected character
+	DiffExtra                      // Typed a character that isn't in the text
+)
+
+// DiffOp is one step of an alignment. Expected is 0 for DiffExtra and Typed
+// is 0 for DiffMissing.
+type DiffOp struct {
+	Kind     DiffKind
+	Expected rune
+	Typed    rune
+}
+
+// alignBand is how far (in characters) typing may drift from the text before
+// the alignment stops looking for a match; it bounds memory to O(n·band)
+const alignBand = 32
+
+// Align returns the edit sequence with the fewest errors (Levenshtein
+// distance) that turns expected into typed. Unlike comparing position by
+// position, a skipped or doubled character costs one error instead of making
+// everything after it mismatch. Typed may stop anywhere in expected: the rest
+// of the text is not reported as missing.
+func Align(expected, typed []rune) []DiffOp {
+	band := alignBand
+	if len(expected) > len(typed)+band {
+		expected = expected[:len(typed)+band] // Text past the band can't be reached
+	}
+	n, m := len(expected), len(typed)
+	if diff := n - m; diff > band {
+		band = diff
+	} else if -diff > band {
+		band = -diff
+	}
+
+	// cost[i][k] is the distance between expected[:i] and typed[:j], with
+	// k = j - i + band so only the diagonal band is stored
+	const unreachable = int32(1 << 30)
+	width := 2*band + 1
+	cost := make([]int32, (n+1)*width)
+	move := make([]DiffKind, (n+1)*width)
+	at := func(i, j int) int { return i*width + j - i + band }
+	for i := range cost {
+		cost[i] = unreachable
+	}
+
+	for i := 0; i <= n; i++ {
+		lo, hi := max(0, i-band), min(m, i+band)
+		for j := lo; j <= hi; j++ {
+			idx := at(i, j)
+			switch {
+			case i == 0 && j == 0:
+				cost[idx] = 0
+				continue
+			case i == 0:
+				cost[idx], move[idx] = int32(j), DiffExtra
+				continue
+			case j == 0:
+				cost[idx], move[idx] = int32(i), DiffMissing
+				continue
+			}
+
+			// Prefer the diagonal on ties so errors are reported as substitutions
+			best, kind := cost[at(i-1, j-1)], DiffMatch
+			if expected[i-1] != typed[j-1] {
+				best, kind = best+1, DiffSubstitute
+			}
+			if j-1 >= i-band {
+				if c := cost[at(i, j-1)] + 1; c < best {
+					best, kind = c, DiffExtra
+				}
+			}
+			if j <= i-1+band {
+				if c := cost[at(i-1, j)] + 1; c < best {
+					best, kind = c, DiffMissing
+				}
+			}
+			cost[idx], move[idx] = best, kind
+		}
+	}
+
+	// Typing may end part way through the text: finish at the cheapest row
+	// that consumed all of typed, preferring the longest match of the text
+	end := max(0, m-band)
+	for i := end; i <= min(n, m+band); i++ {
+		if cost[at(i, m)] <= cost[at(end, m)] {
+			end = i
+		}
+	}
+
+	var ops []DiffOp
+	for i, j := end, m; i > 0 || j > 0; {
+		switch kind := move[at(i, j)]; kind {
+		case DiffMatch, DiffSubstitute:
+			ops = append(ops, DiffOp{Kind: kind, Expected: expected[i-1], Typed: typed[j-1]})
+			i, j = i-1, j-1
+		case DiffMissing:
+			ops = append(ops, DiffOp{Kind: kind, Expected: expected[i-1]})
+			i--
+		case DiffExtra:
+			ops = append(ops, DiffOp{Kind: kind, Typed: typed[j-1]})
+			j--
+		}
+	}
+	for l, r := 0, len(ops)-1; l < r; l, r = l+1, r-1 {
+		ops[l], ops[r] = ops[r], ops[l]
+	}
+	return ops
+}
+
+// TypedAlignment aligns everything typed in this test against the text
+func (g *TypingGame) TypedAlignment() []DiffOp {
+	text := strings.Join(strings.Fields(strings.Join(g.AllWords, " ")), " ")
+	return Align([]rune(text), []rune(g.UserInput))
+}
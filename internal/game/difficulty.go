@@ -0,0 +1,86 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Difficulty tiers pick words by how common they are. Since the word list is
+// ordered from most to least common, rarer words are also longer on average.
+const (
+	DifficultyEasy   = "easy"   // The 200 most common words, mostly short
+	DifficultyMedium = "medium" // The whole word list
+	DifficultyHard   = "hard"   // Everything but the 400 most common words
+)
+
+// Difficulties lists the difficulty tiers from easiest to hardest
+var Difficulties = []string{DifficultyEasy, DifficultyMedium, DifficultyHard}
+
+// Baseline WPM at which adaptive practice moves up a difficulty tier
+const (
+	MediumDifficultyWPM = 35
+	HardDifficultyWPM   = 70
+)
+
+// DifficultyForWPM returns the tier that keeps a typist at wpm challenged
+func DifficultyForWPM(wpm float64) string {
+	switch {
+	case wpm >= HardDifficultyWPM:
+		return DifficultyHard
+	case wpm >= MediumDifficultyWPM:
+		return DifficultyMedium
+	default:
+		return DifficultyEasy
+	}
+}
+
+// difficultyRange returns the slice of englishWords a tier draws from
+func difficultyRange(tier string) (int, int) {
+	n := len(englishWords)
+	switch tier {
+	case DifficultyEasy:
+		return 0, min(200, n)
+	case DifficultyHard:
+		return min(400, n/2), n
+	default:
+		return 0, n
+	}
+}
+
+// TieredWords returns a WordGenerator that draws from the words of one
+// difficulty tier of language's word list, sampled with dist like the full
+// list. Tiers rank words by how common they are, which only the English list
+// records, so other languages return an error.
+func TieredWords(language, tier, dist string) (WordGenerator, error) {
+	if language != "english" {
+		return nil, fmt.Errorf("difficulty tiers are not available for %s", language)
+	}
+
+	start, end := difficultyRange(tier)
+	return func(count int) []string {
+		if end <= start {
+			return GenerateWords(count)
+		}
+
+		// DistFrequency weighs the tier's words as SeededWordsWithDist weighs
+		// the whole list, by drawing from their share of the cumulative weights
+		low, high := 0.0, englishFrequency[end-1]
+		if start > 0 {
+			low = englishFrequency[start-1]
+		}
+
+		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+		words := make([]string, count)
+		for i := range words {
+			if dist == DistFrequency {
+				index := sort.SearchFloat64s(englishFrequency, low+rng.Float64()*(high-low))
+				words[i] = englishWords[min(max(index, start), end-1)]
+			} else {
+				words[i] = englishWords[start+rng.Intn(end-start)]
+			}
+		}
+		return words
+	}, nil
+}
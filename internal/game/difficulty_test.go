@@ -0,0 +1,49 @@
+package game
+
+import "testing"
+
+func TestTieredWordsStayInTier(t *testing.T) {
+	rank := make(map[string]int, len(englishWords))
+	for i := len(englishWords) - 1; i >= 0; i-- {
+		rank[englishWords[i]] = i // The first occurrence wins if a word repeats
+	}
+
+	for _, tier := range Difficulties {
+		for _, dist := range WordDists {
+			t.Run(tier+"/"+dist, func(t *testing.T) {
+				generate, err := TieredWords("english", tier, dist)
+				if err != nil {
+					t.Fatal(err)
+				}
+				start, end := difficultyRange(tier)
+				common := 0 // Words from the most common tenth of the tier
+				words := generate(2000)
+				for _, word := range words {
+					i := rank[word]
+					if i < start || i >= end {
+						t.Fatalf("%q (rank %d) is outside the %s tier [%d, %d)", word, i, tier, start, end)
+					}
+					if i < start+(end-start)/10 {
+						common++
+					}
+				}
+
+				// Uniform draws take about a tenth from there; frequency draws more,
+				// least so in the hard tier, where the weights are flattest (about 15%)
+				share := float64(common) / float64(len(words))
+				if dist == DistUniform && (share < 0.05 || share > 0.15) {
+					t.Errorf("uniform: %.0f%% of words from the most common tenth, want about 10%%", share*100)
+				}
+				if dist == DistFrequency && share < 0.12 {
+					t.Errorf("frequency: %.0f%% of words from the most common tenth, want more than 12%%", share*100)
+				}
+			})
+		}
+	}
+}
+
+func TestTieredWordsUnknownLanguage(t *testing.T) {
+	if _, err := TieredWords("klingon", DifficultyMedium, DistUniform); err == nil {
+		t.Error("TieredWords accepted a language without a word list")
+	}
+}
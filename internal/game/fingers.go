@@ -0,0 +1,113 @@
+package game
+
+// Finger identifies a finger in standard touch-typing
+type Finger int
+
+const (
+	LeftPinky Finger = iota
+	LeftRing
+	LeftMiddle
+	LeftIndex
+	Thumbs
+	RightIndex
+	RightMiddle
+	RightRing
+	RightPinky
+)
+
+// Fingers lists every finger from left to right
+var Fingers = []Finger{
+	LeftPinky, LeftRing, LeftMiddle, LeftIndex, Thumbs,
+	RightIndex, RightMiddle, RightRing, RightPinky,
+}
+
+var fingerNames = map[Finger]string{
+	LeftPinky:   "L pinky",
+	LeftRing:    "L ring",
+	LeftMiddle:  "L middle",
+	LeftIndex:   "L index",
+	Thumbs:      "thumbs",
+	RightIndex:  "R index",
+	RightMiddle: "R middle",
+	RightRing:   "R ring",
+	RightPinky:  "R pinky",
+}
+
+// String returns a short display name for the finger
+func (f Finger) String() string {
+	return fingerNames[f]
+}
+
+// qwertyFingers maps each key on a US QWERTY keyboard, shifted or not, to the
+// finger that types it in standard touch-typing
+var qwertyFingers = buildFingerMap(map[Finger]string{
+	LeftPinky:   "`~1!qQaAzZ",
+	LeftRing:    "2@wWsSxX",
+	LeftMiddle:  "3#eEdDcC",
+	LeftIndex:   "4$5%rRtTfFgGvVbB",
+	Thumbs:      " ",
+	RightIndex:  "6^7&yYuUhHjJnNmM",
+	RightMiddle: "8*iIkK,<",
+	RightRing:   "9(oOlL.>",
+	RightPinky:  "0)-_=+pP[{]}\\|;:'\"/?",
+})
+
+func buildFingerMap(keys map[Finger]string) map[rune]Finger {
+	fingers := make(map[rune]Finger)
+	for finger, chars := range keys {
+		for _, r := range chars {
+			fingers[r] = finger
+		}
+	}
+	return fingers
+}
+
+// KeyStat counts how often a key was expected and how often it was mistyped
+type KeyStat struct {
+	Attempts int
+	Misses   int
+}
+
+// FingerStat is the accuracy of one finger over the keys it is responsible for
+type FingerStat struct {
+	Finger   Finger
+	Attempts int
+	Misses   int
+}
+
+// Accuracy returns the percentage of this finger's keys typed correctly
+func (f FingerStat) Accuracy() float64 {
+	if f.Attempts == 0 {
+		return 0
+	}
+	return float64(f.Attempts-f.Misses) / float64(f.Attempts) * 100
+}
+
+// recordKey counts an attempt at the expected key, and a miss if typed differs
+func (g *TypingGame) recordKey(expected, typed rune) {
+	stat := g.KeyStats[expected]
+	stat.Attempts++
+	if typed != expected {
+		stat.Misses++
+	}
+	g.KeyStats[expected] = stat
+}
+
+// FingerAccuracy groups the per-key miss counts by touch-typing finger. Every
+// finger is returned, in left-to-right order, even if it typed nothing.
+func (g *TypingGame) FingerAccuracy() []FingerStat {
+	stats := make([]FingerStat, len(Fingers))
+	for i, finger := range Fingers {
+		stats[i].Finger = finger
+	}
+
+	for key, stat := range g.KeyStats {
+		finger, ok := qwertyFingers[key]
+		if !ok {
+			continue
+		}
+		stats[finger].Attempts += stat.Attempts
+		stats[finger].Misses += stat.Misses
+	}
+	return stats
+}
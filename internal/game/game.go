@@ -1,8 +1,14 @@
 package game
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"strings"
 	"time"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
 )
 
 // TypingStats holds the statistics for a game session
@@ -15,8 +21,44 @@ type TypingStats struct {
 	TimeElapsed       time.Duration
 	IsComplete        bool
 	UncorrectedErrors int
+	ErrorsPerMinute   float64
+
+	// Raw counting: every keystroke counts, including characters later deleted
+	Keystrokes  int     // Characters typed, whether or not they were kept
+	Backspaces  int     // Characters deleted with backspace
+	RawWPM      float64 // Keystrokes / 5 / minutes
+	RawAccuracy float64 // Correct keystrokes as a share of all keystrokes, backspaces included
+
+	AccuracyModel string // Formula used for Accuracy (see AccuracyModels)
+
+	// Invalid says why the result can't be trusted, e.g. the machine slept
+	// during the test; empty for a valid result
+	Invalid string
 }
 
+// Accuracy models. Typing sites disagree on what accuracy means, so the
+// formula can be chosen to compare results with them. In every model a typed
+// character is an error if it didn't match the text at the time it was typed.
+const (
+	// AccuracyStandard is zentype's own formula and the only one submitted:
+	// (characters kept - every error made) / characters kept.
+	// Errors count even if they were later corrected.
+	AccuracyStandard = "standard"
+
+	// AccuracyFinal only judges the finished text:
+	// (characters kept - errors still in the text) / characters kept.
+	// Corrected errors are forgiven.
+	AccuracyFinal = "final"
+
+	// AccuracyKeystrokes judges every character key press, similar to MonkeyType:
+	// (characters typed - every error made) / characters typed, where
+	// characters typed includes ones later deleted. Backspaces don't count.
+	AccuracyKeystrokes = "keystrokes"
+)
+
+// AccuracyModels lists the accuracy models in display order
+var AccuracyModels = []string{AccuracyStandard, AccuracyFinal, AccuracyKeystrokes}
+
 // TypingGame represents the state of a game session
 type TypingGame struct {
 	AllWords        []string
@@ -29,41 +71,142 @@ type TypingGame struct {
 	IsStarted       bool
 	IsFinished      bool
 	Errors          map[int]bool
+	Corrected       map[int]bool // Positions that had an error which was backspaced over
+	KeyStats        map[rune]KeyStat // Attempts and misses per expected key
+	Keystrokes      int // Every character typed, including ones later deleted
+	Backspaces      int // Every character deleted
+	KeyLog          []Keystroke // Every accepted key press, in order
+	CaretScroll     bool // Move the caret down through the visible lines before scrolling
+	CurrentLine     int  // Display line the caret is on; always 0 unless CaretScroll is set
 	TotalErrorsMade int
+	AccuracyModel   string // How GetStats calculates Accuracy; empty means AccuracyStandard
 	LinesPerView    int
-	CharsPerLine    int
+	CharsPerLine    int // Line width in terminal cells, not runes
 	WordsTyped      int
+	WordTimings     []WordTiming
+	lastWordEnd     time.Time
+	lastTimedPos    int
+	pausedAt        time.Time // When the clock was paused; zero while running
+	undo            []caretStep // One entry per character in UserInput, for backspace
+	now             func() time.Time // Clock used for all timing; time.Now unless replaced
+	generate        WordGenerator    // Source of additional words when running low
+}
+
+// caretStep records where the caret was before a character was accepted, so
+// backspace can put it back exactly, including across a line break or scroll
+type caretStep struct {
+	line       int      // CurrentLine before the character
+	pos        int      // CurrentPos before the character
+	wordsTyped int      // WordsTyped before a line break
+	lines      []string // DisplayLines before a line break; nil for other characters
+}
+
+// WordGenerator produces count words for a test
+type WordGenerator func(count int) []string
+
+// MinRatedElapsed is the shortest test time for which WPM and other per-minute
+// rates are calculated; shorter runs report them as 0
+const MinRatedElapsed = time.Second
+
+// Sanity limits for a result. Elapsed time is measured on the monotonic clock,
+// which stops while the machine sleeps, so a wall clock that has moved much
+// further (or backwards) means the test was suspended or the clock was changed
+// part way through, and its rates can't be trusted.
+const (
+	MaxClockDrift   = 2 * time.Second // Allowed disagreement between wall and monotonic elapsed time
+	MaxPlausibleWPM = 350.0           // Faster results are treated as a timing glitch
+)
+
+// MinWordPool is the fewest non-empty words a game needs to fill its display
+const MinWordPool = 20
+
+// ErrNotEnoughWords is returned when the word pool is too small to start a test
+var ErrNotEnoughWords = errors.New("not enough words to start a typing test")
+
+// Bounds and assumptions for sizing the first batch of generated words
+const (
+	minInitialWords  = 100  // Enough to fill the display and rarely extend on short tests
+	maxInitialWords  = 1000 // Long tests extend as they go rather than front-loading
+	openInitialWords = 200  // Open-ended tests have no duration to size from
+	expectedWPM      = 120  // A fast typist; slower ones simply never reach the end
+	wordMargin       = 1.5  // Headroom over the estimate before extension kicks in
+)
+
+// InitialWordCount estimates how many words a test of duration seconds needs up
+// front, so short tests start quickly and long ones rarely need extending
+func InitialWordCount(duration int) int {
+	if duration <= 0 {
+		return openInitialWords
+	}
+
+	count := int(float64(duration) * expectedWPM / 60 * wordMargin)
+	if count < minInitialWords {
+		return minInitialWords
+	}
+	if count > maxInitialWords {
+		return maxInitialWords
+	}
+	return count
 }
 
 // NewTypingGame initializes a new TypingGame instance with a specified duration
-func NewTypingGame(duration int) *TypingGame {
-	// Generate random words from the English word list
-	words := GenerateWords(200) // Generate 200 random words for the session
-	
-	game := &TypingGame{
-		AllWords:     words,
-		Duration:     duration,
-		Errors:       make(map[int]bool),
-		LinesPerView: 3,
-		CharsPerLine: 50,
+func NewTypingGame(duration int) (*TypingGame, error) {
+	return NewTypingGameWithGenerator(duration, GenerateWords)
+}
+
+// NewTypingGameWithGenerator initializes a new TypingGame whose words (including
+// the ones added as the test runs) come from generate
+func NewTypingGameWithGenerator(duration int, generate WordGenerator) (*TypingGame, error) {
+	words := generate(InitialWordCount(duration))
+
+	game, err := NewTypingGameWithWords(duration, words)
+	if err != nil {
+		return nil, err
 	}
-	game.generateDisplayLines()
-	return game
+	game.generate = generate
+	return game, nil
+}
+
+// NewTypingGameWithWords initializes a new TypingGame instance with existing words.
+// It fails with ErrNotEnoughWords rather than producing a blank, untypeable test.
+func NewTypingGameWithWords(duration int, words []string) (*TypingGame, error) {
+	if err := checkWordPool(words); err != nil {
+		return nil, err
+	}
+	return newTypingGame(duration, words), nil
 }
 
-// NewTypingGameWithWords initializes a new TypingGame instance with existing words
-func NewTypingGameWithWords(duration int, words []string) *TypingGame {
+// newTypingGame builds a game over words without checking the word pool
+func newTypingGame(duration int, words []string) *TypingGame {
 	game := &TypingGame{
 		AllWords:     words,
 		Duration:     duration,
 		Errors:       make(map[int]bool),
+		Corrected:    make(map[int]bool),
+		KeyStats:     make(map[rune]KeyStat),
 		LinesPerView: 3,
 		CharsPerLine: 50,
+		now:          time.Now,
+		generate:     GenerateWords,
 	}
 	game.generateDisplayLines()
 	return game
 }
 
+// checkWordPool verifies there are enough non-empty words to fill the display
+func checkWordPool(words []string) error {
+	count := 0
+	for _, word := range words {
+		if word != "" {
+			count++
+		}
+	}
+	if count < MinWordPool {
+		return fmt.Errorf("%w: got %d, need at least %d", ErrNotEnoughWords, count, MinWordPool)
+	}
+	return nil
+}
+
 // generateDisplayLines creates the initial display lines based on the words available
 func (g *TypingGame) generateDisplayLines() {
 	lines := make([]string, 0, g.LinesPerView)
@@ -72,21 +215,25 @@ func (g *TypingGame) generateDisplayLines() {
 	// Generate exactly g.LinesPerView lines
 	for lineNum := 0; lineNum < g.LinesPerView && wordIndex < len(g.AllWords); lineNum++ {
 		var currentLine strings.Builder
+		lineWidth := 0 // Terminal cells used so far; wide (e.g. CJK) characters take two
 
 		// Fill current line with words
 		for wordIndex < len(g.AllWords) {
 			word := g.AllWords[wordIndex]
+			wordWidth := runewidth.StringWidth(word)
 			spaceNeeded := 0
 			if currentLine.Len() > 0 {
 				spaceNeeded = 1
 			}
 
-			// Check if word fits
-			if currentLine.Len()+spaceNeeded+len(word) <= g.CharsPerLine {
+			// Check if word fits. A word wider than a whole line gets a line
+			// of its own rather than leaving every line empty.
+			if lineWidth+spaceNeeded+wordWidth <= g.CharsPerLine || currentLine.Len() == 0 {
 				if currentLine.Len() > 0 {
 					currentLine.WriteString(" ")
 				}
 				currentLine.WriteString(word)
+				lineWidth += spaceNeeded + wordWidth
 				wordIndex++
 			} else {
 				// Word doesn't fit, break to next line
@@ -111,11 +258,23 @@ func (g *TypingGame) generateDisplayLines() {
 	g.DisplayLines = lines
 }
 
+// SetClock replaces the clock used for timing, allowing elapsed time to be
+// simulated deterministically (e.g. in tests or when replaying keystrokes)
+func (g *TypingGame) SetClock(now func() time.Time) {
+	g.now = now
+}
+
+// SetGenerator replaces the source used to extend the word list as the test runs
+func (g *TypingGame) SetGenerator(generate WordGenerator) {
+	g.generate = generate
+}
+
 // Start initializes the game session if it hasn't started yet
 func (g *TypingGame) Start() {
 	if !g.IsStarted {
-		g.StartTime = time.Now()
+		g.StartTime = g.now()
 		g.IsStarted = true
+		g.lastWordEnd = g.StartTime
 	}
 }
 
@@ -130,22 +289,33 @@ func (g *TypingGame) AddCharacter(char rune) {
 		return
 	}
 
-	lineText := []rune(g.DisplayLines[0])
+	lineText := []rune(g.DisplayLines[g.CurrentLine])
 
 	// If at end of line, only shift if user just typed space
 	if g.CurrentPos == len(lineText) {
 		if char == ' ' {
+			g.recordWordTiming()
+			g.logKey(char, ' ')
+			g.Keystrokes++
+			g.pushLineBreak()
 			g.UserInput += string(char)
 			g.CurrentPos++
 			g.GlobalPos++
-			g.shiftLines()
+			g.advanceLine()
 		}
 		return
 	}
 
 	// Normal character processing
 	if g.CurrentPos < len(lineText) && g.CurrentPos >= 0 {
+		if char == ' ' && lineText[g.CurrentPos] == ' ' {
+			g.recordWordTiming()
+		}
+		g.undo = append(g.undo, caretStep{line: g.CurrentLine, pos: g.CurrentPos})
 		g.UserInput += string(char)
+		g.logKey(char, lineText[g.CurrentPos])
+		g.Keystrokes++
+		g.recordKey(lineText[g.CurrentPos], char)
 		if lineText[g.CurrentPos] != char {
 			g.Errors[g.GlobalPos] = true
 			g.TotalErrorsMade++
@@ -161,22 +331,54 @@ func (g *TypingGame) HandleEnterKey() bool {
 		return false
 	}
 
-	lineText := []rune(g.DisplayLines[0])
+	lineText := []rune(g.DisplayLines[g.CurrentLine])
 
 	// Only allow Enter to progress if at end of line
 	if g.CurrentPos == len(lineText) {
 		// Treat Enter like Space internally for consistency
+		g.recordWordTiming()
+		g.logKey(KeyEnter, ' ')
+		g.pushLineBreak()
 		g.UserInput += " "
 		g.CurrentPos++
 		g.GlobalPos++
-		g.shiftLines()
+		g.advanceLine()
 		return true
 	}
 
 	return false
 }
 
-// shiftLines moves to the next line in the game, updating the words typed and generating new lines
+// pushLineBreak records the caret before the space or Enter that ends a line,
+// keeping the display lines in case moving to the next line scrolls them
+func (g *TypingGame) pushLineBreak() {
+	lines := make([]string, len(g.DisplayLines))
+	copy(lines, g.DisplayLines)
+	g.undo = append(g.undo, caretStep{line: g.CurrentLine, pos: g.CurrentPos, wordsTyped: g.WordsTyped, lines: lines})
+}
+
+// advanceLine moves the caret to the start of the next line. In caret-scroll
+// mode the caret descends through the visible lines and the text only scrolls
+// once it reaches the second-to-last line, so one line of lookahead remains.
+func (g *TypingGame) advanceLine() {
+	if g.CaretScroll && g.CurrentLine < g.LinesPerView-2 {
+		g.CurrentLine++
+		g.CurrentPos = 0
+		return
+	}
+	g.shiftLines()
+}
+
+// CaretOffset returns the caret's rune index within GetDisplayText()
+func (g *TypingGame) CaretOffset() int {
+	offset := 0
+	for _, line := range g.DisplayLines[:g.CurrentLine] {
+		offset += len([]rune(line)) + 1 // +1 for the joining space
+	}
+	return offset + g.CurrentPos
+}
+
+// shiftLines scrolls the top line off the display, updating the words typed and generating new lines
 func (g *TypingGame) shiftLines() {
 	// Move to next line
 	g.WordsTyped += len(strings.Fields(g.DisplayLines[0]))
@@ -187,34 +389,145 @@ func (g *TypingGame) shiftLines() {
 	
 	// Extend words if we're running low (like in typtea)
 	if g.WordsTyped > len(g.AllWords)-50 {
-		newWords := GenerateWords(100)
+		newWords := g.generate(100)
 		g.AllWords = append(g.AllWords, newWords...)
 	}
 }
 
-// RemoveCharacter removes the last character from the user input and updates the position
+// RemoveCharacter removes the last character from the user input, moving the
+// caret back to where it was before that character was typed. This reverses
+// line breaks too, so a mistake on the previous line can still be fixed.
 func (g *TypingGame) RemoveCharacter() {
-	if len(g.UserInput) > 0 && g.CurrentPos > 0 {
-		g.UserInput = g.UserInput[:len(g.UserInput)-1]
-		g.CurrentPos--
+	if len(g.UserInput) > 0 && len(g.undo) > 0 {
+		step := g.undo[len(g.undo)-1]
+		g.undo = g.undo[:len(g.undo)-1]
+		if step.lines != nil {
+			g.DisplayLines = step.lines
+			g.WordsTyped = step.wordsTyped
+		}
+		g.CurrentLine = step.line
+		g.CurrentPos = step.pos
+
+		_, size := utf8.DecodeLastRuneInString(g.UserInput)
+		g.UserInput = g.UserInput[:len(g.UserInput)-size]
 		g.GlobalPos--
+		g.Backspaces++
+		g.logKey(KeyBackspace, 0)
 
-		// Remove error mark if previously added
+		// Remove error mark if previously added, remembering the position was corrected
+		if g.Errors[g.GlobalPos] {
+			g.Corrected[g.GlobalPos] = true
+		}
 		delete(g.Errors, g.GlobalPos)
 	}
 }
 
+// ReachedWords returns the words the user has reached so far, including
+// auto-extended words and a partially typed current word
+func (g *TypingGame) ReachedWords() []string {
+	reached := g.WordsTyped
+	for _, passed := range g.DisplayLines[:g.CurrentLine] {
+		reached += len(strings.Fields(passed))
+	}
+	line := []rune(g.DisplayLines[g.CurrentLine])
+	pos := g.CurrentPos
+	if pos > len(line) {
+		pos = len(line)
+	}
+	reached += len(strings.Fields(string(line[:pos])))
+
+	if reached > len(g.AllWords) {
+		reached = len(g.AllWords)
+	}
+	return g.AllWords[:reached]
+}
+
 // GetDisplayText returns the current text to be displayed in the game
 func (g *TypingGame) GetDisplayText() string {
 	return strings.Join(g.DisplayLines, " ")
 }
 
+// IsOpenEnded reports whether the game has no time limit (Duration == 0)
+func (g *TypingGame) IsOpenEnded() bool {
+	return g.Duration == 0
+}
+
+// Finish ends the session immediately, e.g. when an open-ended test is stopped
+func (g *TypingGame) Finish() {
+	g.IsFinished = true
+}
+
+// Pause stops the test clock, e.g. while review mode shows a mistake. Time
+// spent paused doesn't count towards the test.
+func (g *TypingGame) Pause() {
+	if g.IsStarted && g.pausedAt.IsZero() {
+		g.pausedAt = g.now()
+	}
+}
+
+// Resume restarts the clock after Pause
+func (g *TypingGame) Resume() {
+	if g.pausedAt.IsZero() {
+		return
+	}
+	paused := g.now().Sub(g.pausedAt)
+	g.StartTime = g.StartTime.Add(paused)
+	g.lastWordEnd = g.lastWordEnd.Add(paused)
+	g.pausedAt = time.Time{}
+}
+
+// IsPaused reports whether the clock is stopped by Pause
+func (g *TypingGame) IsPaused() bool {
+	return !g.pausedAt.IsZero()
+}
+
+// elapsed returns how long the test has been running, not counting pauses
+func (g *TypingGame) elapsed() time.Duration {
+	if g.IsPaused() {
+		return g.pausedAt.Sub(g.StartTime)
+	}
+	return g.now().Sub(g.StartTime)
+}
+
 // IsTimeUp checks if the game time has exceeded the specified duration
 func (g *TypingGame) IsTimeUp() bool {
-	if !g.IsStarted {
+	if !g.IsStarted || g.IsOpenEnded() {
 		return false
 	}
-	return time.Since(g.StartTime).Seconds() >= float64(g.Duration)
+	return g.elapsed().Seconds() >= float64(g.Duration)
+}
+
+// TimeLeft returns the exact time until a timed test ends, and false for tests
+// that are open-ended or haven't started
+func (g *TypingGame) TimeLeft() (time.Duration, bool) {
+	if !g.IsStarted || g.IsOpenEnded() {
+		return 0, false
+	}
+	left := time.Duration(g.Duration)*time.Second - g.elapsed()
+	if left < 0 {
+		left = 0
+	}
+	return left, true
+}
+
+// estimateAfter is how long a test runs before its speed is steady enough to
+// project from
+const estimateAfter = 3 * time.Second
+
+// WordsLeftEstimate projects how many more words will be typed before a timed
+// test ends at the current gross speed, counting five characters as a word.
+// It returns false for open-ended tests and during the first few seconds.
+func (g *TypingGame) WordsLeftEstimate() (int, bool) {
+	left, ok := g.TimeLeft()
+	if !ok {
+		return 0, false
+	}
+	elapsed := g.elapsed()
+	if elapsed < estimateAfter {
+		return 0, false
+	}
+	wpm := float64(g.GlobalPos) / 5 / elapsed.Minutes()
+	return int(math.Round(wpm * left.Minutes())), true
 }
 
 // GetRemainingTime returns the remaining time in seconds for the game
@@ -222,7 +535,7 @@ func (g *TypingGame) GetRemainingTime() int {
 	if !g.IsStarted {
 		return g.Duration
 	}
-	elapsed := int(time.Since(g.StartTime).Seconds())
+	elapsed := int(g.elapsed().Seconds())
 	remaining := g.Duration - elapsed
 	if remaining < 0 {
 		return 0
@@ -230,16 +543,50 @@ func (g *TypingGame) GetRemainingTime() int {
 	return remaining
 }
 
+// GetElapsedTime returns the elapsed time in whole seconds since the game started
+func (g *TypingGame) GetElapsedTime() int {
+	if !g.IsStarted {
+		return 0
+	}
+	return int(g.elapsed().Seconds())
+}
+
+// Elapsed returns how long the game has been running, not counting pauses
+func (g *TypingGame) Elapsed() time.Duration {
+	if !g.IsStarted {
+		return 0
+	}
+	return g.elapsed()
+}
+
+// ClockJumped reports whether the wall clock has drifted from the monotonic
+// clock by more than MaxClockDrift since the test started. Clocks replaced
+// with SetClock carry no monotonic reading and never report a jump.
+func (g *TypingGame) ClockJumped() bool {
+	if !g.IsStarted {
+		return false
+	}
+	now := g.now()
+	monotonic := now.Sub(g.StartTime)
+	wall := now.Round(0).Sub(g.StartTime.Round(0)) // Round(0) strips the monotonic reading
+	drift := wall - monotonic
+	return drift > MaxClockDrift || drift < -MaxClockDrift
+}
+
 // GetStats calculates and returns the typing statistics for the current game session
 func (g *TypingGame) GetStats() TypingStats {
 	if !g.IsStarted {
 		return TypingStats{}
 	}
 
-	elapsed := time.Since(g.StartTime)
+	elapsed := g.elapsed()
+	if elapsed < 0 {
+		elapsed = 0 // The wall clock moved backwards
+	}
 	
 	// If time is up, use exact test duration for accurate calculations
-// This ensures WPM calculation uses the intended time (e.g., exactly 15s)
+// This ensures WPM calculation uses the intended time (e.g., exactly 15s).
+// Open-ended tests are never "up", so they always use the real elapsed time.
 var timeForCalculation time.Duration
 if g.IsTimeUp() {
     timeForCalculation = time.Duration(g.Duration) * time.Second
@@ -249,17 +596,59 @@ if g.IsTimeUp() {
 	
 	minutes := timeForCalculation.Minutes()
 
+	// Rates over a fraction of a second (e.g. a test finished instantly) are
+	// meaningless and can be astronomically large, so they are reported as 0
+	rated := timeForCalculation >= MinRatedElapsed
+
 	// Calculate standard WPM (Gross WPM - total characters typed / 5 / minutes)
 	wpm := 0.0
-	if minutes > 0 {
+	if rated {
 		wpm = float64(g.GlobalPos) / 5 / minutes
 	}
 
 	// Calculate accuracy (correct characters / total characters typed * 100)
 	correctChars := g.GlobalPos - g.TotalErrorsMade
+	if correctChars < 0 {
+		correctChars = 0
+	}
+	model := g.AccuracyModel
+	if model == "" {
+		model = AccuracyStandard
+	}
 	accuracy := 0.0
-	if g.GlobalPos > 0 {
-		accuracy = float64(correctChars) / float64(g.GlobalPos) * 100
+	switch model {
+	case AccuracyFinal:
+		if g.GlobalPos > 0 {
+			accuracy = float64(g.GlobalPos-len(g.Errors)) / float64(g.GlobalPos) * 100
+		}
+	case AccuracyKeystrokes:
+		if g.Keystrokes > 0 {
+			accuracy = float64(g.Keystrokes-g.TotalErrorsMade) / float64(g.Keystrokes) * 100
+		}
+	default:
+		if g.GlobalPos > 0 {
+			accuracy = float64(correctChars) / float64(g.GlobalPos) * 100
+		}
+	}
+
+	// Calculate error rate (errors made / minutes), independent of how much was typed
+	errorsPerMinute := 0.0
+	if rated {
+		errorsPerMinute = float64(g.TotalErrorsMade) / minutes
+	}
+
+	// Raw counting treats deleted characters and the backspaces that removed
+	// them as real keystrokes, so corrections cost speed and accuracy
+	rawWPM := 0.0
+	if rated {
+		rawWPM = float64(g.Keystrokes) / 5 / minutes
+	}
+	rawAccuracy := 0.0
+	if total := g.Keystrokes + g.Backspaces; total > 0 {
+		rawAccuracy = float64(g.Keystrokes-g.TotalErrorsMade) / float64(total) * 100
+	}
+	if rawAccuracy < 0 {
+		rawAccuracy = 0
 	}
 
 	// Ensure values don't go below 0
@@ -270,14 +659,37 @@ if g.IsTimeUp() {
 		accuracy = 0
 	}
 
+	invalid := ""
+	switch {
+	case g.ClockJumped():
+		invalid = "the system clock jumped during the test (sleep or clock change)"
+	case wpm > MaxPlausibleWPM:
+		invalid = fmt.Sprintf("%.0f WPM is faster than anyone types", wpm)
+	}
+
 	return TypingStats{
-		WPM:               wpm,  // Use standard WPM, not Net WPM
-		Accuracy:          accuracy,
+		WPM:               finite(wpm),  // Use standard WPM, not Net WPM
+		Accuracy:          finite(accuracy),
 		CharactersTyped:   g.GlobalPos,
 		CorrectChars:      correctChars,
 		TotalChars:        len([]rune(g.GetDisplayText())),
 		TimeElapsed:       timeForCalculation,
 		IsComplete:        g.IsFinished,
 		UncorrectedErrors: len(g.Errors),
+		ErrorsPerMinute:   finite(errorsPerMinute),
+		Keystrokes:        g.Keystrokes,
+		Backspaces:        g.Backspaces,
+		RawWPM:            finite(rawWPM),
+		RawAccuracy:       finite(rawAccuracy),
+		AccuracyModel:     model,
+		Invalid:           invalid,
+	}
+}
+
+// finite replaces NaN and ±Inf with 0 so no stat can break rendering or submission
+func finite(v float64) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
 	}
+	return v
 }
\ No newline at end of file
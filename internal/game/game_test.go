@@ -0,0 +1,236 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// repeatWords returns n copies of word, enough to pass the word pool check
+func repeatWords(word string, n int) []string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = word
+	}
+	return words
+}
+
+func TestDisplayLinesFitWideCharacters(t *testing.T) {
+	// Each word is three CJK characters, six terminal cells wide
+	g, err := NewTypingGameWithWords(0, repeatWords("日本語", MinWordPool))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, line := range g.DisplayLines {
+		if width := runewidth.StringWidth(line); width > g.CharsPerLine {
+			t.Errorf("line %d is %d cells wide, more than %d: %q", i, width, g.CharsPerLine, line)
+		}
+	}
+
+	// 7 words take 7*6 + 6 spaces = 48 cells; an 8th would need 55
+	if got := len(strings.Fields(g.DisplayLines[0])); got != 7 {
+		t.Errorf("first line holds %d words, want 7", got)
+	}
+}
+
+func TestDisplayLinesOverlongWord(t *testing.T) {
+	long := strings.Repeat("長", 40) // 80 cells, wider than any line
+	words := append([]string{long}, repeatWords("ok", MinWordPool)...)
+	g, err := NewTypingGameWithWords(0, words)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g.DisplayLines[0] != long {
+		t.Errorf("first line = %q, want the overlong word on its own", g.DisplayLines[0])
+	}
+	if g.DisplayLines[1] == "" {
+		t.Error("the words after an overlong word were not laid out")
+	}
+}
+
+func TestRemoveCharacterMultibyte(t *testing.T) {
+	g, err := NewTypingGameWithWords(0, repeatWords("héllo", MinWordPool))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, char := range "hé" {
+		g.AddCharacter(char)
+	}
+	g.RemoveCharacter()
+
+	if g.UserInput != "h" {
+		t.Errorf("UserInput = %q after backspacing over 'é', want %q", g.UserInput, "h")
+	}
+	if g.CurrentPos != 1 || g.GlobalPos != 1 {
+		t.Errorf("caret at %d (global %d), want 1", g.CurrentPos, g.GlobalPos)
+	}
+}
+
+// checkFinite fails the test if any rate in stats is NaN, infinite or negative
+func checkFinite(t *testing.T, stats TypingStats) {
+	t.Helper()
+	rates := map[string]float64{
+		"WPM":             stats.WPM,
+		"Accuracy":        stats.Accuracy,
+		"ErrorsPerMinute": stats.ErrorsPerMinute,
+		"RawWPM":          stats.RawWPM,
+		"RawAccuracy":     stats.RawAccuracy,
+	}
+	for name, value := range rates {
+		if math.IsNaN(value) || math.IsInf(value, 0) || value < 0 {
+			t.Errorf("%s = %v, want a finite, non-negative value", name, value)
+		}
+	}
+}
+
+func TestGetStatsEdgeCases(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration int
+		typed    string        // Typed before finishing
+		elapsed  time.Duration // Clock time from the first key press to finishing
+		wantWPM  bool          // Whether a positive WPM is expected
+	}{
+		{name: "finished instantly", duration: 60, typed: "", elapsed: 0},
+		{name: "typed with no time passing", duration: 60, typed: "word", elapsed: 0},
+		{name: "under the minimum rated time", duration: 60, typed: "word", elapsed: MinRatedElapsed / 2},
+		{name: "open-ended finished instantly", duration: 0, typed: "w", elapsed: 0},
+		{name: "nothing kept after backspacing", duration: 60, typed: "ab\b\b", elapsed: 10 * time.Second},
+		{name: "normal run", duration: 60, typed: "word", elapsed: 10 * time.Second, wantWPM: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewTypingGameWithWords(tt.duration, repeatWords("word", MinWordPool))
+			if err != nil {
+				t.Fatal(err)
+			}
+			now := time.Unix(0, 0)
+			g.SetClock(func() time.Time { return now })
+
+			g.Start()
+			for _, char := range tt.typed {
+				if char == '\b' {
+					g.RemoveCharacter()
+				} else {
+					g.AddCharacter(char)
+				}
+			}
+			now = now.Add(tt.elapsed)
+			g.Finish()
+
+			stats := g.GetStats()
+			checkFinite(t, stats)
+			if got := stats.WPM > 0; got != tt.wantWPM {
+				t.Errorf("WPM = %v, want positive: %v", stats.WPM, tt.wantWPM)
+			}
+			if stats.Invalid != "" {
+				t.Errorf("result marked invalid: %s", stats.Invalid)
+			}
+		})
+	}
+}
+
+func TestGetStatsNotStarted(t *testing.T) {
+	g, err := NewTypingGameWithWords(60, repeatWords("word", MinWordPool))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Finish()
+	if stats := g.GetStats(); stats != (TypingStats{}) {
+		t.Errorf("stats of a game that never started = %+v, want zero", stats)
+	}
+}
+
+// gameState is the part of a game that backspace must restore exactly
+type gameState struct {
+	UserInput    string
+	CurrentLine  int
+	CurrentPos   int
+	GlobalPos    int
+	WordsTyped   int
+	DisplayLines string
+	Errors       int
+}
+
+func snapshot(g *TypingGame) gameState {
+	return gameState{
+		UserInput:    g.UserInput,
+		CurrentLine:  g.CurrentLine,
+		CurrentPos:   g.CurrentPos,
+		GlobalPos:    g.GlobalPos,
+		WordsTyped:   g.WordsTyped,
+		DisplayLines: strings.Join(g.DisplayLines, "\n"),
+		Errors:       len(g.Errors),
+	}
+}
+
+func TestBackspaceReversesEveryStep(t *testing.T) {
+	for _, caretScroll := range []bool{false, true} {
+		t.Run(fmt.Sprintf("caret scroll %v", caretScroll), func(t *testing.T) {
+			words := make([]string, MinWordPool)
+			for i := range words {
+				words[i] = fmt.Sprintf("w%d", i*37%1000) // Words of varying length
+			}
+			g, err := NewTypingGameWithWords(0, words)
+			if err != nil {
+				t.Fatal(err)
+			}
+			g.CaretScroll = caretScroll
+
+			// history[i] is the state before the i-th character still in the input
+			var history []gameState
+			crossed := 0 // Backspaces that undid a line break
+			backspace := func() {
+				t.Helper()
+				want := history[len(history)-1]
+				history = history[:len(history)-1]
+				if want.CurrentLine != g.CurrentLine || want.WordsTyped != g.WordsTyped {
+					crossed++
+				}
+				g.RemoveCharacter()
+				if got := snapshot(g); got != want {
+					t.Fatalf("after backspace at depth %d:\n got %+v\nwant %+v", len(history), got, want)
+				}
+			}
+
+			// Type several lines, with a mistake every 7th key and three
+			// backspaces every 11th, so backspaces land on line breaks too
+			for i := 1; g.WordsTyped < 40; i++ {
+				line := []rune(g.DisplayLines[g.CurrentLine])
+				char := ' '
+				if g.CurrentPos < len(line) {
+					char = line[g.CurrentPos]
+				}
+				if i%7 == 0 && char != ' ' {
+					char = 'x'
+				}
+
+				history = append(history, snapshot(g))
+				g.AddCharacter(char)
+				if i%11 == 0 {
+					for j := 0; j < 3; j++ {
+						backspace()
+					}
+				}
+			}
+
+			for len(history) > 0 {
+				backspace()
+			}
+			if crossed == 0 {
+				t.Error("no backspace crossed a line break")
+			}
+			if g.UserInput != "" || g.GlobalPos != 0 || g.WordsTyped != 0 || len(g.Errors) != 0 {
+				t.Errorf("state not fully reversed: %+v", snapshot(g))
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Keystrokes with these characters are replayed as special keys rather than typed
+const (
+	KeyBackspace = '\b'
+	KeyEnter     = '\n'
+)
+
+// Keystroke is a single recorded key press, At being the offset from the
+// start of the recording
+type Keystroke struct {
+	Char     rune
+	At       time.Duration
+	Expected rune // Character the text expected when logged by a game; 0 for backspaces and replay input
+}
+
+// Correct reports whether a logged key press matched the text. Enter at the
+// end of a line stands in for the space there.
+func (k Keystroke) Correct() bool {
+	return k.Char == k.Expected || (k.Char == KeyEnter && k.Expected == ' ')
+}
+
+// NewReplayGame creates a game over exactly the given text. No extra words are
+// generated once the text runs out, so the result only depends on the input.
+// Unlike a live test, any text with at least one word can be replayed, since
+// nothing has to fill the display.
+func NewReplayGame(duration int, text string) (*TypingGame, error) {
+	words := Tokenize(text)
+	if strings.Join(words, "") == "" {
+		return nil, fmt.Errorf("%w: the text has no words", ErrNotEnoughWords)
+	}
+	game := newTypingGame(duration, words)
+	game.SetGenerator(func(int) []string { return nil })
+	return game, nil
+}
+
+// Replay feeds recorded keystrokes through the game on a simulated clock and
+// returns the resulting stats. Games end at the last keystroke, or when a timed
+// game's duration runs out if that comes first.
+func (g *TypingGame) Replay(keystrokes []Keystroke) TypingStats {
+	base := time.Unix(0, 0)
+	current := base
+	g.SetClock(func() time.Time { return current })
+
+	for _, key := range keystrokes {
+		current = base.Add(key.At)
+		if g.IsFinished || g.IsTimeUp() {
+			g.IsFinished = true
+			break
+		}
+
+		switch key.Char {
+		case KeyBackspace:
+			g.RemoveCharacter()
+		case KeyEnter:
+			g.HandleEnterKey()
+		default:
+			g.AddCharacter(key.Char)
+		}
+	}
+
+	if g.IsStarted && !g.IsOpenEnded() {
+		if end := g.StartTime.Add(time.Duration(g.Duration) * time.Second); current.After(end) {
+			current = end
+		}
+	}
+	g.Finish()
+	return g.GetStats()
+}
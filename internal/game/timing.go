@@ -0,0 +1,269 @@
+package game
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WordTiming records how long the user took to type a single word
+type WordTiming struct {
+	Word     string
+	Duration time.Duration
+}
+
+// WPM returns the typing speed for this word, counting its trailing space
+func (w WordTiming) WPM() float64 {
+	minutes := w.Duration.Minutes()
+	if minutes <= 0 {
+		return 0
+	}
+	return float64(len([]rune(w.Word))+1) / 5 / minutes
+}
+
+// recordWordTiming stores the time taken for the word that ends at the caret.
+// It must be called before the caret advances past the word boundary.
+func (g *TypingGame) recordWordTiming() {
+	// Ignore boundaries that were already timed (e.g. retyped after a backspace)
+	if g.GlobalPos < g.lastTimedPos {
+		return
+	}
+
+	line := []rune(g.DisplayLines[g.CurrentLine])
+	pos := g.CurrentPos
+	if pos > len(line) {
+		pos = len(line)
+	}
+	fields := strings.Fields(string(line[:pos]))
+	if len(fields) == 0 {
+		return
+	}
+
+	now := g.now()
+	g.WordTimings = append(g.WordTimings, WordTiming{
+		Word:     fields[len(fields)-1],
+		Duration: now.Sub(g.lastWordEnd),
+	})
+	g.lastWordEnd = now
+	g.lastTimedPos = g.GlobalPos + 1
+}
+
+// SlowestWords returns up to n completed words with the lowest per-word WPM
+func (g *TypingGame) SlowestWords(n int) []WordTiming {
+	timings := make([]WordTiming, len(g.WordTimings))
+	copy(timings, g.WordTimings)
+
+	sort.SliceStable(timings, func(i, j int) bool {
+		return timings[i].WPM() < timings[j].WPM()
+	})
+
+	if len(timings) > n {
+		timings = timings[:n]
+	}
+	return timings
+}
+
+// logKey appends a key press to the keystroke log, timed from the start of the
+// test, with the character the text expected there
+func (g *TypingGame) logKey(char, expected rune) {
+	g.KeyLog = append(g.KeyLog, Keystroke{Char: char, At: g.elapsed(), Expected: expected})
+}
+
+// IntervalsMillis returns the time between consecutive key presses in whole
+// milliseconds, in the order they were typed
+func (g *TypingGame) IntervalsMillis() []int {
+	if len(g.KeyLog) < 2 {
+		return nil
+	}
+	intervals := make([]int, len(g.KeyLog)-1)
+	for i := 1; i < len(g.KeyLog); i++ {
+		intervals[i-1] = int((g.KeyLog[i].At - g.KeyLog[i-1].At).Milliseconds())
+	}
+	return intervals
+}
+
+// KeystrokeIntervals returns the mean and median time between consecutive key
+// presses, and false if fewer than two keys were pressed
+func (g *TypingGame) KeystrokeIntervals() (mean, median time.Duration, ok bool) {
+	if len(g.KeyLog) < 2 {
+		return 0, 0, false
+	}
+
+	intervals := make([]time.Duration, 0, len(g.KeyLog)-1)
+	var total time.Duration
+	for i := 1; i < len(g.KeyLog); i++ {
+		interval := g.KeyLog[i].At - g.KeyLog[i-1].At
+		intervals = append(intervals, interval)
+		total += interval
+	}
+
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i] < intervals[j] })
+	mid := len(intervals) / 2
+	median = intervals[mid]
+	if len(intervals)%2 == 0 {
+		median = (intervals[mid-1] + intervals[mid]) / 2
+	}
+
+	return total / time.Duration(len(intervals)), median, true
+}
+
+// GhostOffset returns where a typist going at a steady wpm since the start of
+// the test would be in GetDisplayText, and false if that is not on screen
+func (g *TypingGame) GhostOffset(wpm float64) (int, bool) {
+	if !g.IsStarted || wpm <= 0 {
+		return 0, false
+	}
+
+	chars := int(wpm * 5 * g.elapsed().Minutes())
+	offset := g.CaretOffset() + chars - g.GlobalPos
+	if offset < 0 || offset >= len([]rune(g.GetDisplayText())) {
+		return 0, false
+	}
+	return offset, true
+}
+
+// PeakWindow is the length of the sliding window used for peak WPM
+const PeakWindow = 10 * time.Second
+
+// PeakWPM returns the highest gross WPM over any window of the given length,
+// counting characters typed minus characters deleted in the window. It returns
+// false if the key presses span less than one window.
+func (g *TypingGame) PeakWPM(window time.Duration) (float64, bool) {
+	if window <= 0 || len(g.KeyLog) == 0 || g.KeyLog[len(g.KeyLog)-1].At < window {
+		return 0, false
+	}
+
+	// positions[i] is the number of characters typed after KeyLog[i]
+	positions := make([]int, len(g.KeyLog))
+	pos := 0
+	for i, key := range g.KeyLog {
+		if key.Char == KeyBackspace {
+			pos--
+		} else {
+			pos++
+		}
+		positions[i] = pos
+	}
+
+	// For each key press ending a window, find the position just before the window began
+	best := 0
+	start := 0 // First key press inside the current window
+	for end, key := range g.KeyLog {
+		for g.KeyLog[start].At <= key.At-window {
+			start++
+		}
+		before := 0
+		if start > 0 {
+			before = positions[start-1]
+		}
+		if typed := positions[end] - before; typed > best {
+			best = typed
+		}
+	}
+
+	return float64(best) / 5 / window.Minutes(), true
+}
+
+// SustainWindow is how long recent WPM must hold the target in --until-wpm mode
+const SustainWindow = 15 * time.Second
+
+// RecentWPM returns the net WPM over the window ending now, counting the
+// correct characters typed in it that are still in the input, so mistakes
+// and deleted text don't help reach a target. It returns false until the test
+// has been running for a whole window.
+func (g *TypingGame) RecentWPM(window time.Duration) (float64, bool) {
+	if !g.IsStarted || window <= 0 {
+		return 0, false
+	}
+	elapsed := g.elapsed()
+	if elapsed < window {
+		return 0, false
+	}
+
+	// Replay the log to find the key presses that survived every backspace
+	kept := make([]Keystroke, 0, len(g.KeyLog))
+	for _, key := range g.KeyLog {
+		if key.Char != KeyBackspace {
+			kept = append(kept, key)
+		} else if len(kept) > 0 {
+			kept = kept[:len(kept)-1]
+		}
+	}
+
+	correct := 0
+	for i := len(kept) - 1; i >= 0 && kept[i].At > elapsed-window; i-- {
+		if kept[i].Correct() {
+			correct++
+		}
+	}
+	return float64(correct) / 5 / window.Minutes(), true
+}
+
+// wpmOver returns the gross WPM over the window ending at elapsed, counting
+// characters typed minus characters deleted in it
+func (g *TypingGame) wpmOver(elapsed, window time.Duration) float64 {
+	typed := 0
+	for i := len(g.KeyLog) - 1; i >= 0 && g.KeyLog[i].At > elapsed-window; i-- {
+		if g.KeyLog[i].Char == KeyBackspace {
+			typed--
+		} else {
+			typed++
+		}
+	}
+	if typed < 0 {
+		typed = 0
+	}
+	return float64(typed) / 5 / window.Minutes()
+}
+
+// LiveWindow is how far back the live WPM counter measures the current speed
+const LiveWindow = 5 * time.Second
+
+// LiveWPM returns the current gross WPM over the last LiveWindow (or the whole
+// test while it is shorter than that). It returns false during the first few
+// seconds, when a handful of characters would swing it wildly.
+func (g *TypingGame) LiveWPM() (float64, bool) {
+	if !g.IsStarted {
+		return 0, false
+	}
+	elapsed := g.elapsed()
+	if elapsed < estimateAfter {
+		return 0, false
+	}
+	return g.wpmOver(elapsed, min(LiveWindow, elapsed)), true
+}
+
+// WPMSmoother steadies a live WPM figure with an exponential moving average.
+// The average is weighted by time rather than by update, so it behaves the
+// same however often the counter is redrawn.
+type WPMSmoother struct {
+	// Smoothing is the share of the previous value kept after one second:
+	// 0 shows the raw speed and values close to 1 change very slowly
+	Smoothing float64
+
+	value   float64
+	at      time.Duration
+	started bool
+}
+
+// Update blends the speed measured at elapsed into the average and returns it.
+// The first update starts the average at that speed.
+func (s *WPMSmoother) Update(elapsed time.Duration, wpm float64) float64 {
+	if !s.started {
+		s.value, s.at, s.started = wpm, elapsed, true
+		return s.value
+	}
+	if elapsed <= s.at {
+		return s.value
+	}
+	keep := math.Pow(s.Smoothing, (elapsed - s.at).Seconds())
+	s.value = keep*s.value + (1-keep)*wpm
+	s.at = elapsed
+	return s.value
+}
+
+// Value returns the current average, and false before the first update
+func (s *WPMSmoother) Value() (float64, bool) {
+	return s.value, s.started
+}
@@ -0,0 +1,153 @@
+package game
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// typeSteadily types text into g one character every step on its clock
+func typeSteadily(g *TypingGame, now *time.Time, text string, step time.Duration) {
+	for _, char := range text {
+		if char == '\b' {
+			g.RemoveCharacter()
+		} else {
+			g.AddCharacter(char)
+		}
+		*now = now.Add(step)
+	}
+}
+
+func TestRecentWPMCountsCorrectCharacters(t *testing.T) {
+	tests := []struct {
+		name string
+		text string // Typed one character every 100ms, filling the window
+		want float64
+	}{
+		// 150 correct characters in 15s is 120 WPM
+		{name: "all correct", text: strings.Repeat("word ", 30), want: 120},
+		{name: "all wrong", text: strings.Repeat("xxxx ", 30), want: 24}, // Only the spaces are right
+		{name: "typed then deleted", text: strings.Repeat("wo\b\b", 37) + "wo", want: 1.6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewTypingGameWithWords(0, repeatWords("word", 200))
+			if err != nil {
+				t.Fatal(err)
+			}
+			start := time.Unix(0, 0)
+			now := start
+			g.SetClock(func() time.Time { return now })
+			g.Start()
+
+			now = now.Add(100 * time.Millisecond)
+			typeSteadily(g, &now, tt.text, 100*time.Millisecond)
+			now = start.Add(SustainWindow + 50*time.Millisecond)
+
+			got, ok := g.RecentWPM(SustainWindow)
+			if !ok {
+				t.Fatal("RecentWPM not ready after a whole window")
+			}
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("RecentWPM = %.2f, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
+
+// liveStream plays a synthetic test: a typist who alternates bursts of fast and
+// slow typing every two seconds, sampled every 100ms like a redrawing counter.
+// It returns the raw and smoothed live WPM from each sample once LiveWPM is ready.
+func liveStream(t *testing.T, smoothing float64, length time.Duration) (raw, smoothed []float64) {
+	t.Helper()
+	g, err := NewTypingGameWithWords(0, repeatWords("word", 500))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Unix(0, 0)
+	now := start
+	g.SetClock(func() time.Time { return now })
+	g.Start()
+
+	smoother := WPMSmoother{Smoothing: smoothing}
+	text := []rune(strings.Repeat("word ", 500))
+	next := 0
+	nextKey := time.Duration(0)
+	for at := time.Duration(0); at <= length; at += 10 * time.Millisecond {
+		now = start.Add(at)
+		for nextKey <= at {
+			g.AddCharacter(text[next])
+			next++
+			if (at/(2*time.Second))%2 == 0 {
+				nextKey += 100 * time.Millisecond // 120 WPM
+			} else {
+				nextKey += 300 * time.Millisecond // 40 WPM
+			}
+		}
+		if at%(100*time.Millisecond) != 0 {
+			continue
+		}
+		if wpm, ok := g.LiveWPM(); ok {
+			raw = append(raw, wpm)
+			smoothed = append(smoothed, smoother.Update(g.Elapsed(), wpm))
+		}
+	}
+	return raw, smoothed
+}
+
+// jitter returns how far a counter showing values moves in total
+func jitter(values []float64) float64 {
+	total := 0.0
+	for i := 1; i < len(values); i++ {
+		total += math.Abs(values[i] - values[i-1])
+	}
+	return total
+}
+
+func TestWPMSmootherSteadiesLiveWPM(t *testing.T) {
+	raw, unsmoothed := liveStream(t, 0, 30*time.Second)
+	if !reflect.DeepEqual(raw, unsmoothed) {
+		t.Error("smoothing 0 changed the live WPM")
+	}
+
+	raw, smoothed := liveStream(t, 0.8, 30*time.Second)
+	// Skip the first samples, where the average starts from a single reading
+	raw, smoothed = raw[20:], smoothed[20:]
+	if jitter(smoothed) >= jitter(raw)/2 {
+		t.Errorf("smoothed WPM moves %.1f in total, raw %.1f; want under half", jitter(smoothed), jitter(raw))
+	}
+
+	// The average follows the typist: 120 and 40 WPM in equal measure
+	mean := 0.0
+	for _, wpm := range smoothed {
+		mean += wpm
+	}
+	mean /= float64(len(smoothed))
+	if mean < 60 || mean > 100 {
+		t.Errorf("smoothed WPM averages %.1f, want about 80", mean)
+	}
+}
+
+func TestWPMSmootherIgnoresUpdateRate(t *testing.T) {
+	// The same speed change seen at different redraw rates ends at the same value
+	fast := WPMSmoother{Smoothing: 0.6}
+	slow := WPMSmoother{Smoothing: 0.6}
+	fast.Update(0, 50)
+	slow.Update(0, 50)
+	for at := 100 * time.Millisecond; at <= 3*time.Second; at += 100 * time.Millisecond {
+		fast.Update(at, 100)
+	}
+	slow.Update(3*time.Second, 100)
+
+	f, _ := fast.Value()
+	s, _ := slow.Value()
+	if math.Abs(f-s) > 1e-9 {
+		t.Errorf("updated every 100ms: %.4f, once: %.4f; want equal", f, s)
+	}
+	if want := 100 - 50*math.Pow(0.6, 3); math.Abs(f-want) > 1e-9 {
+		t.Errorf("value after 3s = %.4f, want %.4f", f, want)
+	}
+}
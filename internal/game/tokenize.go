@@ -0,0 +1,53 @@
+package game
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// MaxTokenWidth is the widest word Tokenize returns, in terminal cells. Longer
+// runs are split so every word fits on a line and the display never stalls on
+// a word it can't place.
+const MaxTokenWidth = 30
+
+// Tokenize splits arbitrary text (a file, pasted text, a transcript) into
+// words a test can use. Any run of Unicode whitespace separates words, so tabs,
+// newlines and non-breaking spaces all become single spaces. Characters that
+// can't be typed or take no room on screen (control and format characters,
+// combining marks, invalid UTF-8) are dropped. It never returns an empty word.
+func Tokenize(text string) []string {
+	var words []string
+	var word strings.Builder
+	width := 0
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+			width = 0
+		}
+	}
+
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			flush()
+			continue
+		}
+		if r == utf8.RuneError || !unicode.IsGraphic(r) {
+			continue
+		}
+		w := runewidth.RuneWidth(r)
+		if w == 0 {
+			continue
+		}
+		if width+w > MaxTokenWidth {
+			flush()
+		}
+		word.WriteRune(r)
+		width += w
+	}
+	flush()
+	return words
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "mixed whitespace", text: " one\ttwo\r\n\nthree four ", want: []string{"one", "two", "three", "four"}},
+		{name: "control characters", text: "a\x00b\x1bc d\u200be", want: []string{"abc", "de"}},
+		{name: "invalid UTF-8", text: "ok\xff\xfe fine", want: []string{"ok", "fine"}},
+		{name: "combining marks", text: "cafe\u0301", want: []string{"cafe"}},
+		{name: "emoji", text: "hi 🙂 there", want: []string{"hi", "🙂", "there"}},
+		{name: "overlong word", text: strings.Repeat("a", 65), want: []string{strings.Repeat("a", 30), strings.Repeat("a", 30), "aaaaa"}},
+		{name: "only whitespace", text: " \t\n ", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Tokenize(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func FuzzTokenize(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"the quick brown fox",
+		" \t\r\n\v\f  ",
+		"\x00\x01\x7f\u200b\u200d\ufeff",
+		"\xff\xfe\xc3",
+		"e\u0301\u0301\u0301",
+		"👩\u200d👩\u200d👧 🇯🇵 日本語",
+		strings.Repeat("長", 100),
+		strings.Repeat("word ", 1000),
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		words := Tokenize(text)
+		for _, word := range words {
+			if word == "" {
+				t.Fatalf("empty word from %q", text)
+			}
+			if !utf8.ValidString(word) {
+				t.Fatalf("invalid UTF-8 word %q from %q", word, text)
+			}
+			if width := runewidth.StringWidth(word); width == 0 || width > MaxTokenWidth {
+				t.Fatalf("word %q is %d cells wide", word, width)
+			}
+			if strings.IndexFunc(word, unicode.IsSpace) >= 0 {
+				t.Fatalf("word %q contains whitespace", word)
+			}
+		}
+
+		// Normalizing is stable: the words joined by spaces tokenize to themselves
+		if again := Tokenize(strings.Join(words, " ")); !reflect.DeepEqual(again, words) {
+			t.Fatalf("Tokenize is not stable: %q then %q", words, again)
+		}
+
+		// Any text with words can be laid out and played
+		if len(words) > 0 {
+			g, err := NewReplayGame(0, text)
+			if err != nil {
+				t.Fatalf("NewReplayGame(%q): %v", text, err)
+			}
+			if g.DisplayLines[0] == "" {
+				t.Fatalf("no first line for %q", text)
+			}
+		}
+	})
+}
@@ -1,112 +1,50 @@
 package game
 
 import (
+	_ "embed"
 	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
-// englishWords contains the most common English words for typing practice
-var englishWords = []string{
-	"the", "of", "to", "and", "a", "in", "is", "it", "you", "that",
-	"he", "was", "for", "on", "are", "with", "as", "his", "they",
-	"be", "at", "one", "have", "this", "from", "or", "had", "by", "not",
-	"word", "but", "what", "some", "we", "can", "out", "other", "were", "all",
-	"there", "when", "up", "use", "your", "how", "said", "an", "each", "she",
-	"which", "do", "their", "time", "if", "will", "way", "about", "many", "then",
-	"them", "write", "would", "like", "so", "these", "her", "long", "make", "thing",
-	"see", "him", "two", "has", "look", "more", "day", "could", "go", "come",
-	"did", "number", "sound", "no", "most", "people", "my", "over", "know", "water",
-	"than", "call", "first", "who", "may", "down", "side", "been", "now", "find",
-	"any", "new", "work", "part", "take", "get", "place", "made", "live", "where",
-	"after", "back", "little", "only", "round", "man", "year", "came", "show", "every",
-	"good", "me", "give", "our", "under", "name", "very", "through", "just", "form",
-	"sentence", "great", "think", "say", "help", "low", "line", "differ", "turn", "cause",
-	"much", "mean", "before", "move", "right", "boy", "old", "too", "same", "tell",
-	"does", "set", "three", "want", "air", "well", "also", "play", "small", "end",
-	"put", "home", "read", "hand", "port", "large", "spell", "add", "even", "land",
-	"here", "must", "big", "high", "such", "follow", "act", "why", "ask", "men",
-	"change", "went", "light", "kind", "off", "need", "house", "picture", "try", "us",
-	"again", "animal", "point", "mother", "world", "near", "build", "self", "earth", "father",
-	"head", "stand", "own", "page", "should", "country", "found", "answer", "school", "grow",
-	"study", "still", "learn", "plant", "cover", "food", "sun", "four", "between", "state",
-	"keep", "eye", "never", "last", "let", "thought", "city", "tree", "cross", "farm",
-	"hard", "start", "might", "story", "saw", "far", "sea", "draw", "left", "late",
-	"run", "while", "press", "close", "night", "real", "life", "few", "north", "open",
-	"seem", "together", "next", "white", "children", "begin", "got", "walk", "example", "ease",
-	"paper", "group", "always", "music", "those", "both", "mark", "often", "letter", "until",
-	"mile", "river", "car", "feet", "care", "second", "book", "carry", "took", "science",
-	"eat", "room", "friend", "began", "idea", "fish", "mountain", "stop", "once", "base",
-	"hear", "horse", "cut", "sure", "watch", "color", "face", "wood", "main", "enough",
-	"plain", "girl", "usual", "young", "ready", "above", "ever", "red", "list", "though",
-	"feel", "talk", "bird", "soon", "body", "dog", "family", "direct", "pose", "leave",
-	"song", "measure", "door", "product", "black", "short", "numeral", "class", "wind", "question",
-	"happen", "complete", "ship", "area", "half", "rock", "order", "fire", "south", "problem",
-	"piece", "told", "knew", "pass", "since", "top", "whole", "king", "space", "heard",
-	"best", "hour", "better", "true", "during", "hundred", "five", "remember", "step", "early",
-	"hold", "west", "ground", "interest", "reach", "fast", "verb", "sing", "listen", "six",
-	"table", "travel", "less", "morning", "ten", "simple", "several", "vowel", "toward", "war",
-	"lay", "against", "pattern", "slow", "center", "love", "person", "money", "serve", "appear",
-	"road", "map", "rain", "rule", "govern", "pull", "cold", "notice", "voice", "unit",
-	"power", "town", "fine", "certain", "fly", "fall", "lead", "cry", "dark", "machine",
-	"note", "wait", "plan", "figure", "star", "box", "noun", "field", "rest", "correct",
-	"able", "pound", "done", "beauty", "drive", "stood", "contain", "front", "teach", "week",
-	"final", "gave", "green", "oh", "quick", "develop", "ocean", "warm", "free", "minute",
-	"strong", "special", "mind", "behind", "clear", "tail", "produce", "fact", "street", "inch",
-	"multiply", "nothing", "course", "stay", "wheel", "full", "force", "blue", "object", "decide",
-	"surface", "deep", "moon", "island", "foot", "system", "busy", "test", "record", "boat",
-	"common", "gold", "possible", "plane", "stead", "dry", "wonder", "laugh", "thousand", "ago",
-	"ran", "check", "game", "shape", "equate", "hot", "miss", "brought", "heat", "snow",
-	"tire", "bring", "yes", "distant", "fill", "east", "paint", "language", "among", "grand",
-	"ball", "yet", "wave", "drop", "heart", "am", "present", "heavy", "dance", "engine",
-	"position", "arm", "wide", "sail", "material", "size", "vary", "settle", "speak", "weight",
-	"general", "ice", "matter", "circle", "pair", "include", "divide", "syllable", "felt", "perhaps",
-	"pick", "sudden", "count", "square", "reason", "length", "represent", "art", "subject", "region",
-	"energy", "hunt", "probable", "bed", "brother", "egg", "ride", "cell", "believe", "fraction",
-	"forest", "sit", "race", "window", "store", "summer", "train", "sleep", "prove", "lone",
-	"leg", "exercise", "wall", "catch", "mount", "wish", "sky", "board", "joy", "winter",
-	"sat", "written", "wild", "instrument", "kept", "glass", "grass", "cow", "job", "edge",
-	"sign", "visit", "past", "soft", "fun", "bright", "gas", "weather", "month", "million",
-	"bear", "finish", "happy", "hope", "flower", "clothes", "strange", "gone", "jump", "baby",
-	"eight", "village", "meet", "root", "buy", "raise", "solve", "metal", "whether", "push",
-	"seven", "paragraph", "third", "shall", "held", "hair", "describe", "cook", "floor", "either",
-	"result", "burn", "hill", "safe", "cat", "century", "consider", "type", "law", "bit",
-	"coast", "copy", "phrase", "silent", "tall", "sand", "soil", "roll", "temperature", "finger",
-	"industry", "value", "fight", "lie", "beat", "excite", "natural", "view", "sense", "ear",
-	"else", "quite", "broke", "case", "middle", "kill", "son", "lake", "moment", "scale",
-	"loud", "spring", "observe", "child", "straight", "consonant", "nation", "dictionary", "milk", "speed",
-	"method", "organ", "pay", "age", "section", "dress", "cloud", "surprise", "quiet", "stone",
-	"tiny", "climb", "cool", "design", "poor", "lot", "experiment", "bottom", "key", "iron",
-	"single", "stick", "flat", "twenty", "skin", "smile", "crease", "hole", "trade", "melody",
-	"trip", "office", "receive", "row", "mouth", "exact", "symbol", "die", "least", "trouble",
-	"shout", "except", "wrote", "seed", "tone", "join", "suggest", "clean", "break", "lady",
-	"yard", "rise", "bad", "blow", "oil", "blood", "touch", "grew", "cent", "mix",
-	"team", "wire", "cost", "lost", "brown", "wear", "garden", "equal", "sent", "choose",
-	"fell", "fit", "flow", "fair", "bank", "collect", "save", "control", "decimal", "gentle",
-	"woman", "captain", "practice", "separate", "difficult", "doctor", "please", "protect", "noon", "whose",
-	"locate", "ring", "character", "insect", "caught", "period", "indicate", "radio", "spoke", "atom",
-	"human", "history", "effect", "electric", "expect", "crop", "modern", "element", "hit", "student",
-	"corner", "party", "supply", "bone", "rail", "imagine", "provide", "agree", "thus", "capital",
-	"chair", "danger", "fruit", "rich", "thick", "soldier", "process", "operate", "guess", "necessary",
-	"sharp", "wing", "create", "neighbor", "wash", "bat", "rather", "crowd", "corn", "compare",
-	"poem", "string", "bell", "depend", "meat", "rub", "tube", "famous", "dollar", "stream",
-	"fear", "sight", "thin", "triangle", "planet", "hurry", "chief", "colony", "clock", "mine",
-	"tie", "enter", "major", "fresh", "search", "send", "yellow", "gun", "allow", "print",
-	"dead", "spot", "desert", "suit", "current", "lift", "rose", "continue", "block", "chart",
-	"hat", "sell", "success", "company", "subtract", "event", "particular", "deal", "swim", "term",
-	"opposite", "wife", "shoe", "shoulder", "spread", "arrange", "camp", "invent", "cotton", "born",
-	"determine", "quart", "nine", "truck", "noise", "level", "chance", "gather", "shop", "stretch",
-	"throw", "shine", "property", "column", "molecule", "select", "wrong", "gray", "repeat", "require",
-	"broad", "prepare", "salt", "nose", "plural", "anger", "claim", "continent", "oxygen", "sugar",
-	"death", "pretty", "skill", "women", "season", "solution", "magnet", "silver", "thank", "branch",
-	"match", "suffix", "especially", "fig", "afraid", "huge", "sister", "steel", "discuss", "forward",
-	"similar", "guide", "experience", "score", "apple", "bought", "led", "pitch", "coat", "mass",
-	"card", "band", "rope", "slip", "win", "dream", "evening", "condition", "feed", "tool",
-	"total", "basic", "smell", "valley", "nor", "double", "seat", "arrive", "master", "track",
-	"parent", "shore", "division", "sheet", "substance", "favor", "connect", "post", "spend", "chord",
-	"fat", "glad", "original", "share", "station", "dad", "bread", "charge", "proper", "bar",
-	"offer", "segment", "duck", "instant", "market", "degree", "populate", "chick", "dear", "enemy",
-	"reply", "drink", "occur", "support", "speech", "nature", "range", "steam", "motion", "path",
-	"liquid", "log", "meant", "quotient", "teeth", "shell", "neck", "program", "public", "universe",
+// Languages lists the word list languages available for tests, in display order
+var Languages = []string{"english"}
+
+// englishList is the English word list, one word per line. It is embedded in
+// the binary so tests never need the network or any file on disk.
+//
+//go:embed wordlists/english.txt
+var englishList string
+
+// englishWords contains the most common English words for typing practice,
+// ordered from most to least common
+var englishWords = strings.Fields(englishList)
+
+// Word distributions for sampling the word list
+const (
+	DistUniform   = "uniform"   // Every word equally often, for vocabulary variety (default)
+	DistFrequency = "frequency" // Common words more often, so tests read like natural English
+)
+
+// WordDists lists the word distributions in display order
+var WordDists = []string{DistUniform, DistFrequency}
+
+// englishFrequency holds the cumulative sampling weights of englishWords for
+// DistFrequency. Since the list is ordered by how common each word is, a word's
+// weight follows the Zipf-Mandelbrot law 1/(rank+2.7), a close fit for English.
+var englishFrequency = zipfCumulative(len(englishWords))
+
+// zipfCumulative returns running totals of Zipf-Mandelbrot weights for n ranks
+func zipfCumulative(n int) []float64 {
+	cumulative := make([]float64, n)
+	total := 0.0
+	for rank := range cumulative {
+		total += 1 / (float64(rank+1) + 2.7)
+		cumulative[rank] = total
+	}
+	return cumulative
 }
 
 // GenerateWords generates a slice of random words from the English word list
@@ -128,6 +66,140 @@ func GenerateWords(count int) []string {
 	return words
 }
 
+// GeneratorVersion identifies the word list and sampling algorithm used by
+// SeededWords. Bump it whenever either changes, since the same seed will then
+// produce different words and old seeds can no longer be replayed exactly.
+const GeneratorVersion = 1
+
+// SeededWords returns a WordGenerator that draws from the English word list in
+// a fixed order determined by seed. Successive calls continue the same sequence,
+// so a fresh generator is needed to replay the words from the start.
+func SeededWords(seed int64) WordGenerator {
+	rng := rand.New(rand.NewSource(seed))
+	return func(count int) []string {
+		words := make([]string, count)
+		for i := range words {
+			words[i] = englishWords[rng.Intn(len(englishWords))]
+		}
+		return words
+	}
+}
+
+// SeededWordsWithDist is SeededWords with a choice of word distribution.
+// DistUniform gives exactly the same words as SeededWords for the same seed.
+func SeededWordsWithDist(seed int64, dist string) WordGenerator {
+	if dist != DistFrequency || len(englishFrequency) == 0 {
+		return SeededWords(seed)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	total := englishFrequency[len(englishFrequency)-1]
+	return func(count int) []string {
+		words := make([]string, count)
+		for i := range words {
+			index := sort.SearchFloat64s(englishFrequency, rng.Float64()*total)
+			if index >= len(englishWords) {
+				index = len(englishWords) - 1
+			}
+			words[i] = englishWords[index]
+		}
+		return words
+	}
+}
+
+// DailySeed returns the seed shared by everyone playing the daily challenge on
+// the UTC date of t, e.g. 20261016
+func DailySeed(t time.Time) int64 {
+	t = t.UTC()
+	return int64(t.Year()*10000 + int(t.Month())*100 + t.Day())
+}
+
+// DrillWords returns a WordGenerator that builds pseudo-words of 2-6 characters
+// drawn only from the given alphabet. Whitespace in the alphabet is ignored.
+func DrillWords(alphabet string) WordGenerator {
+	var chars []rune
+	seen := make(map[rune]bool)
+	for _, r := range alphabet {
+		if r == ' ' || r == '\t' || r == '\n' || seen[r] {
+			continue
+		}
+		seen[r] = true
+		chars = append(chars, r)
+	}
+
+	return func(count int) []string {
+		if len(chars) == 0 {
+			return nil
+		}
+
+		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+		words := make([]string, count)
+		for i := range words {
+			word := make([]rune, 2+rng.Intn(5))
+			for j := range word {
+				word[j] = chars[rng.Intn(len(chars))]
+			}
+			words[i] = string(word)
+		}
+		return words
+	}
+}
+
+// ngramList holds the most frequent English bigrams and trigrams, one per line
+// with its frequency in hundredths of a percent of all n-grams of its length
+//
+//go:embed wordlists/ngrams.txt
+var ngramList string
+
+// ngrams and ngramFrequency are ngramList parsed into the n-grams and their
+// cumulative sampling weights, so common transitions come up most often
+var ngrams, ngramFrequency = parseNgrams(ngramList)
+
+// parseNgrams parses "ngram weight" lines, skipping any that are malformed
+func parseNgrams(list string) ([]string, []float64) {
+	var grams []string
+	var cumulative []float64
+	total := 0.0
+	for _, line := range strings.Split(list, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		weight, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || weight <= 0 {
+			continue
+		}
+		total += weight
+		grams = append(grams, fields[0])
+		cumulative = append(cumulative, total)
+	}
+	return grams, cumulative
+}
+
+// NgramWords returns a WordGenerator that builds pseudo-words by joining two
+// or three frequent English bigrams and trigrams (e.g. "thing", "ionre"), to
+// drill the key transitions that slow most typists down
+func NgramWords() WordGenerator {
+	return func(count int) []string {
+		if len(ngrams) == 0 {
+			return nil
+		}
+
+		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+		total := ngramFrequency[len(ngramFrequency)-1]
+		words := make([]string, count)
+		for i := range words {
+			var word strings.Builder
+			for parts := 2 + rng.Intn(2); parts > 0; parts-- {
+				index := sort.SearchFloat64s(ngramFrequency, rng.Float64()*total)
+				word.WriteString(ngrams[min(index, len(ngrams)-1)])
+			}
+			words[i] = word.String()
+		}
+		return words
+	}
+}
+
 // GetWordCount returns the total number of available English words
 func GetWordCount() int {
 	return len(englishWords)
@@ -0,0 +1,64 @@
+package game
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestOfflineTest plays a whole test with no home directory, no ZenType
+// environment and no network, which must work because the word list is
+// embedded in the binary
+func TestOfflineTest(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	for _, env := range os.Environ() {
+		if name, _, _ := strings.Cut(env, "="); strings.HasPrefix(name, "ZENTYPE_") {
+			t.Setenv(name, "")
+			os.Unsetenv(name)
+		}
+	}
+	t.Setenv("HTTP_PROXY", "http://127.0.0.1:1") // Any network use would fail
+	t.Setenv("HTTPS_PROXY", "http://127.0.0.1:1")
+
+	if GetWordCount() < MinWordPool {
+		t.Fatalf("embedded word list has %d words, want at least %d", GetWordCount(), MinWordPool)
+	}
+
+	g, err := NewTypingGame(15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Unix(0, 0)
+	now := start
+	g.SetClock(func() time.Time { return now })
+
+	// Type the first two lines perfectly, one character every 100ms
+	for line := 0; line < 2; line++ {
+		for _, char := range g.DisplayLines[0] {
+			g.AddCharacter(char)
+			now = now.Add(100 * time.Millisecond)
+		}
+		g.AddCharacter(' ')
+		now = now.Add(100 * time.Millisecond)
+	}
+
+	now = start.Add(15 * time.Second)
+	g.Finish()
+	stats := g.GetStats()
+	if stats.WPM <= 0 || stats.Accuracy != 100 {
+		t.Errorf("offline test scored %.1f WPM at %.1f%% accuracy, want a positive WPM at 100%%", stats.WPM, stats.Accuracy)
+	}
+}
+
+func TestGenerateWordsUsesEmbeddedList(t *testing.T) {
+	known := make(map[string]bool, len(englishWords))
+	for _, word := range englishWords {
+		known[word] = true
+	}
+	for _, word := range GenerateWords(200) {
+		if !known[word] {
+			t.Errorf("generated word %q is not in the embedded list", word)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Entry represents the result of a single completed typing test
+type Entry struct {
+	Timestamp       time.Time `json:"timestamp"`
+	WPM             float64   `json:"wpm"`
+	Accuracy        float64   `json:"accuracy"`
+	ErrorsPerMinute float64   `json:"errors_per_minute"`
+	Duration        int       `json:"duration"`
+	Language        string    `json:"language"`
+	Seed            int64     `json:"seed,omitempty"` // Word seed, replayable with zt --seed
+	// GeneratorVersion is the game.GeneratorVersion the seed was used with.
+	// Entries recorded before versioning have 0, meaning version 1.
+	GeneratorVersion int `json:"generator_version,omitempty"`
+	// WordDist is the word distribution the seed was used with; empty means uniform
+	WordDist string `json:"word_dist,omitempty"`
+	// AccuracyModel is the game accuracy model Accuracy was calculated with;
+	// empty means the standard model
+	AccuracyModel string `json:"accuracy_model,omitempty"`
+	// Keys holds attempts and misses per expected character, keyed by the character
+	Keys map[string]KeyStat `json:"keys,omitempty"`
+}
+
+// KeyStat counts how often a key was expected and how often it was mistyped
+type KeyStat struct {
+	Attempts int `json:"attempts"`
+	Misses   int `json:"misses"`
+}
+
+// FindSeed returns the most recent entry that used seed, if any
+func FindSeed(entries []Entry, seed int64) (Entry, bool) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Seed == seed {
+			return entries[i], true
+		}
+	}
+	return Entry{}, false
+}
+
+// Store handles reading and writing the local test history
+type Store struct {
+	path string
+}
+
+// NewStore creates a history store backed by ~/.zentype/history.json
+func NewStore() (*Store, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	configDir := filepath.Join(homeDir, ".zentype")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	return &Store{path: filepath.Join(configDir, "history.json")}, nil
+}
+
+// Load reads all history entries, oldest first
+func (s *Store) Load() ([]Entry, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil // No history yet
+		}
+		return nil, err
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse history: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Append adds an entry to the end of the history file
+func (s *Store) Append(entry Entry) error {
+	entries, err := s.Load()
+	if err != nil {
+		return err
+	}
+
+	entries = append(entries, entry)
+	return s.save(entries)
+}
+
+// save writes the given entries to disk
+func (s *Store) save(entries []Entry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0600)
+}
+
+// Export file identification. Exports wrap the entries so the format can
+// evolve; a bare history.json array is also accepted on import.
+const (
+	ExportFormat  = "zentype-history"
+	ExportVersion = 1
+)
+
+// exportFile is the on-disk layout of a history export
+type exportFile struct {
+	Format  string  `json:"format"`
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+// Export writes all entries in the export format
+func (s *Store) Export(w io.Writer) error {
+	entries, err := s.Load()
+	if err != nil {
+		return err
+	}
+	if entries == nil {
+		entries = []Entry{}
+	}
+
+	data, err := json.MarshalIndent(exportFile{Format: ExportFormat, Version: ExportVersion, Entries: entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// ParseExport reads entries from an export file or a plain history.json,
+// rejecting unknown formats, newer versions and entries with impossible values
+func ParseExport(data []byte) ([]Entry, error) {
+	var entries []Entry
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("failed to parse history: %w", err)
+		}
+	} else {
+		var file exportFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse history export: %w", err)
+		}
+		if file.Format != ExportFormat {
+			return nil, fmt.Errorf("not a zentype history export (format %q)", file.Format)
+		}
+		if file.Version < 1 || file.Version > ExportVersion {
+			return nil, fmt.Errorf("unsupported history export version %d (this zentype reads up to %d)", file.Version, ExportVersion)
+		}
+		entries = file.Entries
+	}
+
+	for i, entry := range entries {
+		if err := validateEntry(entry); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+	}
+	return entries, nil
+}
+
+// validateEntry checks that an imported entry could have been recorded by zentype
+func validateEntry(entry Entry) error {
+	switch {
+	case entry.Timestamp.IsZero():
+		return fmt.Errorf("missing timestamp")
+	case entry.WPM < 0 || math.IsNaN(entry.WPM) || math.IsInf(entry.WPM, 0):
+		return fmt.Errorf("invalid wpm %v", entry.WPM)
+	case entry.Accuracy < 0 || entry.Accuracy > 100:
+		return fmt.Errorf("invalid accuracy %v", entry.Accuracy)
+	case entry.Duration < 0:
+		return fmt.Errorf("invalid duration %d", entry.Duration)
+	}
+	return nil
+}
+
+// Import merges entries into the stored history in timestamp order, skipping
+// runs that are already present, and returns how many were added
+func (s *Store) Import(entries []Entry) (int, error) {
+	existing, err := s.Load()
+	if err != nil {
+		return 0, err
+	}
+
+	merged, added := Merge(existing, entries)
+	if added == 0 {
+		return 0, nil
+	}
+	return added, s.save(merged)
+}
+
+// Merge combines two histories sorted by timestamp, dropping entries of b that
+// duplicate a run already in a (or earlier in b). It returns the merged entries
+// and the number taken from b.
+func Merge(a, b []Entry) ([]Entry, int) {
+	seen := make(map[string]bool, len(a)+len(b))
+	merged := make([]Entry, 0, len(a)+len(b))
+	for _, entry := range a {
+		seen[entryKey(entry)] = true
+		merged = append(merged, entry)
+	}
+
+	added := 0
+	for _, entry := range b {
+		key := entryKey(entry)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, entry)
+		added++
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Timestamp.Before(merged[j].Timestamp)
+	})
+	return merged, added
+}
+
+// entryKey identifies a run; the same run exported from two machines has an
+// identical timestamp and result
+func entryKey(entry Entry) string {
+	return fmt.Sprintf("%d|%.2f|%.2f|%d|%s|%d",
+		entry.Timestamp.UnixNano(), entry.WPM, entry.Accuracy, entry.Duration, entry.Language, entry.Seed)
+}
+
+// Problem is an issue Verify found with one entry of the history file
+type Problem struct {
+	Entry int    // 1-based position in the file
+	Issue string // What is wrong with it
+}
+
+// Report describes what Verify found and, when repairing, what it changed
+type Report struct {
+	Entries    int       // Entries in the file
+	Problems   []Problem // Unreadable, invalid and duplicate entries, which a repair removes
+	Unsorted   bool      // Entries are not in timestamp order, which a repair fixes
+	Repaired   bool      // The file was rewritten
+	BackupPath string    // Copy of the file before it was repaired
+}
+
+// OK reports whether the history had nothing to fix
+func (r Report) OK() bool {
+	return len(r.Problems) == 0 && !r.Unsorted
+}
+
+// Verify checks every history entry for values zentype could not have
+// recorded, entries that can't be read and duplicated runs. With repair set it
+// removes the bad entries, sorts the rest by timestamp and saves them, keeping
+// the original file next to it with a .bak suffix. A file that isn't a JSON
+// array at all can't be repaired and is returned as an error.
+func (s *Store) Verify(repair bool) (Report, error) {
+	var report Report
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return report, nil
+		}
+		return report, err
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return report, fmt.Errorf("history is not a list of entries, so it can't be repaired: %w", err)
+	}
+	report.Entries = len(raw)
+
+	seen := make(map[string]int, len(raw))
+	kept := make([]Entry, 0, len(raw))
+	for i, message := range raw {
+		var entry Entry
+		if err := json.Unmarshal(message, &entry); err != nil {
+			report.Problems = append(report.Problems, Problem{Entry: i + 1, Issue: fmt.Sprintf("unreadable: %v", err)})
+			continue
+		}
+		if err := validateEntry(entry); err != nil {
+			report.Problems = append(report.Problems, Problem{Entry: i + 1, Issue: err.Error()})
+			continue
+		}
+		key := entryKey(entry)
+		if first, ok := seen[key]; ok {
+			report.Problems = append(report.Problems, Problem{Entry: i + 1, Issue: fmt.Sprintf("duplicate of entry %d", first)})
+			continue
+		}
+		seen[key] = i + 1
+		kept = append(kept, entry)
+	}
+	report.Unsorted = !sort.SliceIsSorted(kept, func(i, j int) bool {
+		return kept[i].Timestamp.Before(kept[j].Timestamp)
+	})
+
+	if !repair || report.OK() {
+		return report, nil
+	}
+
+	report.BackupPath = s.path + ".bak"
+	if err := os.WriteFile(report.BackupPath, data, 0600); err != nil {
+		return report, fmt.Errorf("failed to back up history: %w", err)
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].Timestamp.Before(kept[j].Timestamp)
+	})
+	if err := s.save(kept); err != nil {
+		return report, err
+	}
+	report.Repaired = true
+	return report, nil
+}
@@ -0,0 +1,117 @@
+package localboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Rules for a result to count, the same as the global leaderboard
+const (
+	TargetDuration = 60   // Only 60-second tests count
+	MinAccuracy    = 85.0 // Minimum accuracy to be ranked
+)
+
+// Score is one result recorded under a local nickname
+type Score struct {
+	Name      string    `json:"name"`
+	WPM       float64   `json:"wpm"`
+	Accuracy  float64   `json:"accuracy"`
+	Duration  int       `json:"duration"`
+	Language  string    `json:"language"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Qualifies reports whether the score can be ranked
+func (s Score) Qualifies() bool {
+	return s.Duration == TargetDuration && s.Accuracy >= MinAccuracy
+}
+
+// Store handles reading and writing the local leaderboard
+type Store struct {
+	path string
+}
+
+// NewStore creates a local leaderboard backed by ~/.zentype/local_board.json
+func NewStore() (*Store, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	configDir := filepath.Join(homeDir, ".zentype")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	return &Store{path: filepath.Join(configDir, "local_board.json")}, nil
+}
+
+// Load reads every recorded score, oldest first
+func (s *Store) Load() ([]Score, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil // Nobody has played yet
+		}
+		return nil, err
+	}
+
+	var scores []Score
+	if err := json.Unmarshal(data, &scores); err != nil {
+		return nil, fmt.Errorf("failed to parse local leaderboard: %w", err)
+	}
+	return scores, nil
+}
+
+// Record adds a score to the board
+func (s *Store) Record(score Score) error {
+	scores, err := s.Load()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(append(scores, score), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0600)
+}
+
+// Best returns each nickname's best qualifying score in language, best first.
+// Like the global board, ties go to the higher accuracy and then whoever set
+// the score first. Nicknames are compared without regard to case.
+func Best(scores []Score, language string) []Score {
+	best := make(map[string]Score)
+	for _, score := range scores {
+		if !score.Qualifies() || score.Language != language {
+			continue
+		}
+		key := strings.ToLower(score.Name)
+		if current, ok := best[key]; !ok || better(score, current) {
+			best[key] = score
+		}
+	}
+
+	ranked := make([]Score, 0, len(best))
+	for _, score := range best {
+		ranked = append(ranked, score)
+	}
+	sort.Slice(ranked, func(i, j int) bool { return better(ranked[i], ranked[j]) })
+	return ranked
+}
+
+// better reports whether a ranks above b
+func better(a, b Score) bool {
+	if a.WPM != b.WPM {
+		return a.WPM > b.WPM
+	}
+	if a.Accuracy != b.Accuracy {
+		return a.Accuracy > b.Accuracy
+	}
+	return a.Timestamp.Before(b.Timestamp)
+}
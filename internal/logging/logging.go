@@ -0,0 +1,74 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// MaxSize is the size at which the log is rotated to zentype.log.1
+const MaxSize = 1 << 20 // 1 MiB
+
+var (
+	mu     sync.Mutex
+	logger = log.New(io.Discard, "", log.LstdFlags)
+	file   *os.File
+)
+
+// Path returns the location of the log file (~/.zentype/zentype.log)
+func Path() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".zentype", "zentype.log"), nil
+}
+
+// Enable starts writing log lines to ~/.zentype/zentype.log. An existing log
+// larger than MaxSize is moved to zentype.log.1 first, replacing the old one.
+// Until Enable is called every Printf is discarded.
+func Enable() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if info, err := os.Stat(path); err == nil && info.Size() > MaxSize {
+		if err := os.Rename(path, path+".1"); err != nil {
+			return fmt.Errorf("failed to rotate log: %w", err)
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open log: %w", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	file = f
+	logger.SetOutput(f)
+	return nil
+}
+
+// Close flushes and closes the log file, if one is open
+func Close() {
+	mu.Lock()
+	defer mu.Unlock()
+	if file != nil {
+		file.Close()
+		file = nil
+		logger.SetOutput(io.Discard)
+	}
+}
+
+// Printf writes a timestamped line to the log when logging is enabled
+func Printf(format string, args ...interface{}) {
+	logger.Printf(format, args...)
+}
@@ -0,0 +1,86 @@
+package pending
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Score is a leaderboard submission that couldn't reach the server, kept so
+// it can be sent the next time zt runs
+type Score struct {
+	WPM              float64   `json:"wpm"`
+	Accuracy         float64   `json:"accuracy"`
+	Duration         int       `json:"duration"`
+	Language         string    `json:"language"`
+	Seed             int64     `json:"seed,omitempty"`
+	GeneratorVersion int       `json:"generator_version,omitempty"`
+	WordDist         string    `json:"word_dist,omitempty"`
+	Intervals        []int     `json:"intervals,omitempty"`
+	Timestamp        time.Time `json:"timestamp"`                 // When the test was played
+	IdempotencyKey   string    `json:"idempotency_key,omitempty"` // Sent with every attempt so the server stores the score once
+}
+
+// Store handles reading and writing the queue of pending scores
+type Store struct {
+	path string
+}
+
+// NewStore creates a queue backed by ~/.zentype/pending_scores.json
+func NewStore() (*Store, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	configDir := filepath.Join(homeDir, ".zentype")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	return &Store{path: filepath.Join(configDir, "pending_scores.json")}, nil
+}
+
+// Load reads the queued scores, oldest first
+func (s *Store) Load() ([]Score, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil // Nothing is waiting
+		}
+		return nil, err
+	}
+
+	var scores []Score
+	if err := json.Unmarshal(data, &scores); err != nil {
+		return nil, fmt.Errorf("failed to parse pending scores: %w", err)
+	}
+	return scores, nil
+}
+
+// Add queues a score
+func (s *Store) Add(score Score) error {
+	scores, err := s.Load()
+	if err != nil {
+		return err
+	}
+	return s.Save(append(scores, score))
+}
+
+// Save replaces the queue with scores; an empty queue removes the file
+func (s *Store) Save(scores []Score) error {
+	if len(scores) == 0 {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(scores, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0600)
+}
@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/api"
+	"github.com/nemaniabhiram/zentype.cli/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FeedRefreshInterval is how often the activity feed is re-fetched
+const FeedRefreshInterval = 15 * time.Second
+
+// feedLimit is the number of recent submissions shown
+const feedLimit = 20
+
+// FeedModel represents the live activity feed screen
+type FeedModel struct {
+	width   int
+	height  int
+	client  *api.Client
+	entries []api.ActivityEntry
+	loading bool
+	error   string
+	updated time.Time
+	config  *config.Config // Key bindings
+}
+
+// Message types for the activity feed
+type feedLoadedMsg struct {
+	entries []api.ActivityEntry
+}
+
+type feedErrorMsg struct {
+	error string
+}
+
+type feedRefreshMsg time.Time
+
+// NewFeedModel creates a new activity feed model
+func NewFeedModel() *FeedModel {
+	cfg, _ := config.Load()
+	return &FeedModel{
+		config:  cfg,
+		client:  api.NewClient(),
+		loading: true,
+	}
+}
+
+// Init loads the feed and schedules the first refresh
+func (m FeedModel) Init() tea.Cmd {
+	return tea.Batch(m.loadFeed(), feedRefreshCmd())
+}
+
+func feedRefreshCmd() tea.Cmd {
+	return tea.Tick(FeedRefreshInterval, func(t time.Time) tea.Msg {
+		return feedRefreshMsg(t)
+	})
+}
+
+// Update handles messages for the activity feed
+func (m FeedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.config.Action(msg.String()) {
+		case config.ActionQuit:
+			return m, tea.Quit
+		case config.ActionRefresh:
+			return m, m.loadFeed()
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return m, tea.Quit
+		case "f5":
+			return m, m.loadFeed()
+		}
+		return m, nil
+
+	case feedRefreshMsg:
+		return m, tea.Batch(m.loadFeed(), feedRefreshCmd())
+
+	case feedLoadedMsg:
+		m.entries = msg.entries
+		m.loading = false
+		m.error = ""
+		m.updated = time.Now()
+		return m, nil
+
+	case feedErrorMsg:
+		// Keep showing the last good entries; the error is shown beneath them
+		m.error = msg.error
+		m.loading = false
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// View renders the activity feed
+func (m FeedModel) View() string {
+	var sections []string
+
+	title := accentStyle.
+		Bold(true).
+		Render("⚡ ZenType Activity")
+	sections = append(sections, title, mutedStyle.Render("Recent qualifying 60-second tests"), "")
+
+	switch {
+	case m.loading:
+		sections = append(sections, mutedStyle.Render("Loading activity..."))
+	case len(m.entries) == 0 && m.error == "":
+		sections = append(sections, mutedStyle.Render("No recent activity"))
+	default:
+		sections = append(sections, m.renderEntries())
+	}
+
+	if m.error != "" {
+		sections = append(sections, "", alertStyle.Render(m.error))
+	}
+
+	refresh, quit := controlKeyLabels(m.config)
+	sections = append(sections, "", mutedStyle.Render(fmt.Sprintf("Refreshes every %ds • %s to refresh now • %s to quit", int(FeedRefreshInterval.Seconds()), refresh, quit)))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+}
+
+func (m FeedModel) renderEntries() string {
+	nameStyle := lipgloss.NewStyle().Width(20).Align(lipgloss.Left)
+	wpmStyle := lipgloss.NewStyle().Width(8).Align(lipgloss.Right).Bold(true)
+	accStyle := lipgloss.NewStyle().Width(8).Align(lipgloss.Right)
+	agoStyle := mutedStyle.Copy().Width(10).Align(lipgloss.Right)
+
+	var rows []string
+	for _, entry := range m.entries {
+		displayName := TruncateName(entry.Username, MaxNameWidth)
+		rows = append(rows, lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			nameStyle.Render(displayName), "  ",
+			wpmStyle.Render(fmt.Sprintf("%.0f", entry.WPM)), "  ",
+			accStyle.Render(fmt.Sprintf("%.1f%%", entry.Accuracy)), "  ",
+			agoStyle.Render(timeAgo(time.Since(entry.CreatedAt))),
+		))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// loadFeed fetches the latest activity
+func (m FeedModel) loadFeed() tea.Cmd {
+	return func() tea.Msg {
+		entries, err := m.client.GetActivity(feedLimit)
+		if err != nil {
+			return feedErrorMsg{error: fmt.Sprintf("Failed to load activity: %v", err)}
+		}
+		return feedLoadedMsg{entries: entries}
+	}
+}
+
+// timeAgo formats a duration as a short relative time such as "5m ago"
+func timeAgo(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
@@ -2,13 +2,20 @@ package ui
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 	"github.com/nemaniabhiram/zentype.cli/internal/api"
 	"github.com/nemaniabhiram/zentype.cli/internal/auth"
+	"github.com/nemaniabhiram/zentype.cli/internal/config"
+	"github.com/nemaniabhiram/zentype.cli/internal/game"
+	"github.com/nemaniabhiram/zentype.cli/internal/localboard"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 )
 
 // LeaderboardModel represents the leaderboard screen
@@ -24,16 +31,46 @@ type LeaderboardModel struct {
 	language    string
 	isAuthenticated bool
 	user         *auth.Session
+	serverReachable bool
+	failedAttempts  int
+	active          bool // Rank by qualifying tests played instead of WPM
+	activeEntries   []api.ActiveEntry
+	hidden          int  // Entries left out because their login is in hidden_users
+	local           bool // Show the nickname board stored on this machine instead
+	config          *config.Config // Key bindings and hidden users, loaded once
+	offset          int  // First table row shown when the board is taller than the terminal
+	refreshing      bool // Reloading while the current board stays on screen
+	spinnerFrame    int  // Loading spinner frame, advanced by spinnerTickMsg
+	spinning        bool // A spinnerTickMsg is scheduled
 }
 
+// spinnerFrames are the loading spinner's frames, one per spinner tick
+var spinnerFrames = []string{"⣾", "⣽", "⣻", "⢿", "⡿", "⣟", "⣯", "⣷"}
+
+// SpinnerInterval is how often the loading spinner moves, unless refresh_ms
+// asks for slower redraws
+const SpinnerInterval = 100 * time.Millisecond
+
+// leaderboardChrome is the number of lines around the table rows: the header,
+// the table heading, the user's own entry, the scroll hint and the instructions
+const leaderboardChrome = 16
+
 // Message types for async operations
 type leaderboardLoadedMsg struct {
 	entries   []api.LeaderboardEntry
 	userEntry *api.LeaderboardEntry
 }
 
+type activeLoadedMsg struct {
+	entries []api.ActiveEntry
+}
+
+// spinnerTickMsg advances the loading spinner
+type spinnerTickMsg struct{}
+
 type loadErrorMsg struct {
-	error string
+	error           string
+	serverReachable bool
 }
 
 // NewLeaderboardModel creates a new leaderboard model
@@ -56,19 +93,56 @@ func NewLeaderboardModel() *LeaderboardModel {
 		}
 	}
 
+	cfg, _ := config.Load()
+
 	return &LeaderboardModel{
+		config:          cfg,
 		client:          client,
 		authManager:     authManager,
 		loading:         true,
+		spinning:        true, // Init starts the spinner
 		language:        "english",
 		isAuthenticated: isAuthenticated,
 		user:            user,
 	}
 }
 
+// NewActiveLeaderboardModel creates a leaderboard ranking players by how many
+// qualifying tests they have played
+func NewActiveLeaderboardModel() *LeaderboardModel {
+	m := NewLeaderboardModel()
+	m.active = true
+	return m
+}
+
+// NewLocalLeaderboardModel creates a leaderboard of the nicknames that have
+// played on this machine with zt --name
+func NewLocalLeaderboardModel() *LeaderboardModel {
+	m := NewLeaderboardModel()
+	m.local = true
+	return m
+}
+
 // Init initializes the leaderboard model
 func (m LeaderboardModel) Init() tea.Cmd {
-	return m.loadLeaderboard()
+	return tea.Batch(m.loadLeaderboard(), m.spinnerTickCmd())
+}
+
+// spinnerTickCmd schedules the next spinner frame at the configured redraw rate
+func (m LeaderboardModel) spinnerTickCmd() tea.Cmd {
+	interval := max(SpinnerInterval, time.Duration(m.config.RefreshMS)*time.Millisecond)
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return spinnerTickMsg{}
+	})
+}
+
+// spin starts the spinner's ticks unless they are already running
+func (m *LeaderboardModel) spin() tea.Cmd {
+	if m.spinning {
+		return nil
+	}
+	m.spinning = true
+	return m.spinnerTickCmd()
 }
 
 // Update handles messages for the leaderboard
@@ -80,33 +154,140 @@ func (m LeaderboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		switch m.config.Action(msg.String()) {
+		case config.ActionQuit:
+			return m, tea.Quit
+		case config.ActionRefresh:
+			return m.refresh()
+		}
+
 		switch msg.String() {
-		case "ctrl+c", "esc", "q":
+		case "ctrl+c", "q":
 			return m, tea.Quit
-		case "r", "f5":
-			// Refresh leaderboard
+		case "f5":
+			return m.refresh()
+		case "tab", "L":
+			// Cycle the language filter and re-fetch from the top of the new board
+			m.language = nextLanguage(m.language)
 			m.loading = true
 			m.error = ""
-			return m, m.loadLeaderboard()
+			m.offset = 0
+			spin := m.spin()
+			return m, tea.Batch(m.loadLeaderboard(), spin)
+		case "up", "k":
+			m.offset--
+		case "down", "j":
+			m.offset++
+		case "pgup":
+			m.offset -= m.pageSize()
+		case "pgdown", " ":
+			m.offset += m.pageSize()
+		case "home", "g":
+			m.offset = 0
+		case "end", "G":
+			m.offset = m.rowCount()
 		}
+		m.offset = m.clampOffset(m.offset)
 		return m, nil
 
 	case leaderboardLoadedMsg:
-		m.entries = msg.entries
+		m.entries, m.hidden = m.hideUsers(msg.entries)
 		m.userEntry = msg.userEntry
 		m.loading = false
+		m.refreshing = false
+		m.failedAttempts = 0
+		m.offset = m.clampOffset(m.offset)
+		return m, nil
+
+	case activeLoadedMsg:
+		m.activeEntries, m.hidden = m.hideActiveUsers(msg.entries)
+		m.loading = false
+		m.refreshing = false
+		m.failedAttempts = 0
+		m.offset = m.clampOffset(m.offset)
 		return m, nil
 
+	case spinnerTickMsg:
+		// The ticks stop once loading is done and restart with the next load
+		if !m.loading {
+			m.spinning = false
+			return m, nil
+		}
+		m.spinnerFrame = (m.spinnerFrame + 1) % len(spinnerFrames)
+		return m, m.spinnerTickCmd()
 
 	case loadErrorMsg:
 		m.error = msg.error
+		m.serverReachable = msg.serverReachable
 		m.loading = false
+		m.refreshing = false
+		m.failedAttempts++
 		return m, nil
 	}
 
 	return m, nil
 }
 
+// refresh re-fetches the board. A board already on screen stays there, at
+// the same scroll position, until the new entries arrive.
+func (m LeaderboardModel) refresh() (tea.Model, tea.Cmd) {
+	var spin tea.Cmd
+	if m.rowCount() > 0 && m.error == "" {
+		m.refreshing = true
+	} else {
+		m.loading = true
+		spin = m.spin()
+	}
+	m.error = ""
+	return m, tea.Batch(m.loadLeaderboard(), spin)
+}
+
+// rowCount is the number of entries on the board being shown
+func (m LeaderboardModel) rowCount() int {
+	if m.active {
+		return len(m.activeEntries)
+	}
+	return len(m.entries)
+}
+
+// pageSize is how many table rows fit on screen, or all of them before the
+// terminal size is known
+func (m LeaderboardModel) pageSize() int {
+	if m.height == 0 {
+		return max(m.rowCount(), 1)
+	}
+	return max(m.height-leaderboardChrome, 3)
+}
+
+// clampOffset keeps a scroll offset within the board, so a refresh that
+// returns fewer entries never scrolls past the end
+func (m LeaderboardModel) clampOffset(offset int) int {
+	return max(0, min(offset, m.rowCount()-m.pageSize()))
+}
+
+// visibleRange returns the slice bounds of the table rows shown on screen
+func (m LeaderboardModel) visibleRange() (int, int) {
+	start := m.clampOffset(m.offset)
+	return start, min(start+m.pageSize(), m.rowCount())
+}
+
+// renderScrollHint says how many rows are above and below the visible ones,
+// or returns "" when the whole board fits
+func (m LeaderboardModel) renderScrollHint() string {
+	start, end := m.visibleRange()
+	if start == 0 && end == m.rowCount() {
+		return ""
+	}
+	var parts []string
+	if start > 0 {
+		parts = append(parts, fmt.Sprintf("↑ %d more", start))
+	}
+	if end < m.rowCount() {
+		parts = append(parts, fmt.Sprintf("↓ %d more", m.rowCount()-end))
+	}
+	return mutedStyle.Render(strings.Join(parts, " • "))
+}
+
 // View renders the leaderboard screen
 func (m LeaderboardModel) View() string {
 	if m.loading {
@@ -126,9 +307,16 @@ func (m LeaderboardModel) View() string {
 
 	// Leaderboard table
 	table := m.renderLeaderboardTable()
+	if m.active {
+		table = m.renderActiveTable()
+	}
 	table = lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(table)
 	sections = append(sections, table)
 
+	if hint := m.renderScrollHint(); hint != "" {
+		sections = append(sections, lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(hint))
+	}
+
 
 	// Instructions
 	instructions := m.renderInstructions()
@@ -144,14 +332,23 @@ func (m LeaderboardModel) View() string {
 }
 
 func (m LeaderboardModel) renderHeader() string {
-	title := lipgloss.NewStyle().
+	titleText := "🏆 ZenType Global Leaderboard"
+	subtitleText := fmt.Sprintf("60-second tests • Minimum 85%% accuracy • %s words", languageTitle(m.language))
+	if m.local {
+		titleText = "🏠 ZenType Local Leaderboard"
+		subtitleText = fmt.Sprintf("Players on this machine • 60-second tests • Minimum 85%% accuracy • %s words", languageTitle(m.language))
+	} else if m.active {
+		titleText = "🔥 ZenType Most Active Players"
+		subtitleText = fmt.Sprintf("Qualifying 60-second tests played • %s words", languageTitle(m.language))
+	}
+
+	title := accentStyle.
 		Bold(true).
-		Foreground(lipgloss.Color("12")).
 		Align(lipgloss.Center).
-		Render("🏆 ZenType Global Leaderboard")
+		Render(titleText)
 
 	subtitle := mutedStyle.Align(lipgloss.Center).
-		Render("60-second tests • Minimum 85% accuracy • English words")
+		Render(subtitleText)
 
 	return lipgloss.JoinVertical(lipgloss.Center, title, "", subtitle)
 }
@@ -162,9 +359,8 @@ func (m LeaderboardModel) renderLeaderboardTable() string {
 	}
 
 	// Table styles
-	headerStyle := lipgloss.NewStyle().
+	headerStyle := hintStyle.
 		Bold(true).
-		Foreground(lipgloss.Color("14")).
 		Align(lipgloss.Center)
 
 	rankStyle := lipgloss.NewStyle().
@@ -183,6 +379,10 @@ func (m LeaderboardModel) renderLeaderboardTable() string {
 		Width(8).
 		Align(lipgloss.Right)
 
+	dateStyle := lipgloss.NewStyle().
+		Width(9).
+		Align(lipgloss.Right)
+
 	// Header row
 	headerRow := lipgloss.JoinHorizontal(
 		lipgloss.Top,
@@ -193,40 +393,41 @@ func (m LeaderboardModel) renderLeaderboardTable() string {
 		headerStyle.Copy().Inherit(wpmStyle).Render("WPM"),
 		"  ",
 		headerStyle.Copy().Inherit(accStyle).Render("Accuracy"),
+		"  ",
+		headerStyle.Copy().Inherit(dateStyle).Render("Set"),
 	)
 
 	// Separator
-	separator := strings.Repeat("─", 48)
+	separator := strings.Repeat("─", 59)
 
 	var rows []string
 	rows = append(rows, headerRow)
 	rows = append(rows, mutedStyle.Render(separator))
 
 	// Data rows
-	for _, entry := range m.entries {
+	start, end := m.visibleRange()
+	for _, entry := range m.entries[start:end] {
 		// Highlight current user if authenticated
 		style := lipgloss.NewStyle()
 		if m.isAuthenticated && m.user != nil {
 			if entry.GitHubID == m.user.GitHubID {
-				style = style.Foreground(lipgloss.Color("11")).Bold(true)
+				style = warnStyle.Bold(true)
 			}
 		}
 
 		rank := style.Copy().Inherit(rankStyle).Render(fmt.Sprintf("#%d", entry.Rank))
 		
 		// Truncate long usernames
-		displayName := entry.Username
-		if len(displayName) > 18 {
-			displayName = displayName[:15] + "..."
-		}
+		displayName := TruncateName(entry.Username, MaxNameWidth)
 		name := style.Copy().Inherit(nameStyle).Render(displayName)
 		
-		wpm := style.Copy().Inherit(wpmStyle).Render(fmt.Sprintf("%.0f", entry.WPM))
-		acc := style.Copy().Inherit(accStyle).Render(fmt.Sprintf("%.1f%%", entry.Accuracy))
+		wpm := style.Copy().Inherit(wpmStyle).Render(strconv.FormatFloat(entry.WPM, 'f', m.config.Decimals, 64))
+		acc := style.Copy().Inherit(accStyle).Render(m.formatAccuracy(entry.Accuracy))
+		date := mutedStyle.Copy().Inherit(dateStyle).Render(scoreAge(entry.CreatedAt))
 
 		row := lipgloss.JoinHorizontal(
 			lipgloss.Top,
-			rank, "  ", name, "  ", wpm, "  ", acc,
+			rank, "  ", name, "  ", wpm, "  ", acc, "  ", date,
 		)
 
 		rows = append(rows, row)
@@ -235,26 +436,24 @@ func (m LeaderboardModel) renderLeaderboardTable() string {
 	// Add user's entry below top 10 if they're not in it and authenticated
 	if m.userEntry != nil && m.isAuthenticated && m.user != nil {
 		// Add separator
-		separator2 := strings.Repeat("─", 48)
+		separator2 := strings.Repeat("─", 59)
 		rows = append(rows, mutedStyle.Render(separator2))
 		
 		// User's entry with highlighting
-		userStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true)
+		userStyle := warnStyle.Bold(true)
 		
 		rank := userStyle.Copy().Inherit(rankStyle).Render(fmt.Sprintf("#%d", m.userEntry.Rank))
 		
-		displayName := m.userEntry.Username
-		if len(displayName) > 18 {
-			displayName = displayName[:15] + "..."
-		}
+		displayName := TruncateName(m.userEntry.Username, MaxNameWidth)
 		name := userStyle.Copy().Inherit(nameStyle).Render(displayName)
 		
-		wpm := userStyle.Copy().Inherit(wpmStyle).Render(fmt.Sprintf("%.0f", m.userEntry.WPM))
-		acc := userStyle.Copy().Inherit(accStyle).Render(fmt.Sprintf("%.1f%%", m.userEntry.Accuracy))
+		wpm := userStyle.Copy().Inherit(wpmStyle).Render(strconv.FormatFloat(m.userEntry.WPM, 'f', m.config.Decimals, 64))
+		acc := userStyle.Copy().Inherit(accStyle).Render(m.formatAccuracy(m.userEntry.Accuracy))
+		date := mutedStyle.Copy().Inherit(dateStyle).Render(scoreAge(m.userEntry.CreatedAt))
 		
 		userRow := lipgloss.JoinHorizontal(
 			lipgloss.Top,
-			rank, "  ", name, "  ", wpm, "  ", acc,
+			rank, "  ", name, "  ", wpm, "  ", acc, "  ", date,
 		)
 		
 		rows = append(rows, userRow)
@@ -263,24 +462,126 @@ func (m LeaderboardModel) renderLeaderboardTable() string {
 	return lipgloss.JoinVertical(lipgloss.Left, rows...)
 }
 
+// hideUsers drops entries whose GitHub login is in the config's hidden_users.
+// Ranks are left as the server sent them, so gaps show where users were hidden.
+func (m LeaderboardModel) hideUsers(entries []api.LeaderboardEntry) ([]api.LeaderboardEntry, int) {
+	if len(m.config.HiddenUsers) == 0 {
+		return entries, 0
+	}
+
+	visible := make([]api.LeaderboardEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.GitHubLogin != "" && m.config.IsHidden(entry.GitHubLogin) {
+			continue
+		}
+		visible = append(visible, entry)
+	}
+	return visible, len(entries) - len(visible)
+}
+
+// hideActiveUsers is hideUsers for the --active board
+func (m LeaderboardModel) hideActiveUsers(entries []api.ActiveEntry) ([]api.ActiveEntry, int) {
+	if len(m.config.HiddenUsers) == 0 {
+		return entries, 0
+	}
+
+	visible := make([]api.ActiveEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.GitHubLogin != "" && m.config.IsHidden(entry.GitHubLogin) {
+			continue
+		}
+		visible = append(visible, entry)
+	}
+	return visible, len(entries) - len(visible)
+}
+
+// scoreAge describes how long ago a score was set, or "-" if the server didn't say
+func scoreAge(createdAt time.Time) string {
+	if createdAt.IsZero() {
+		return "-"
+	}
+	return timeAgo(time.Since(createdAt))
+}
+
+// renderActiveTable draws the most-active board with the same styling as the WPM board
+func (m LeaderboardModel) renderActiveTable() string {
+	if len(m.activeEntries) == 0 {
+		return mutedStyle.Align(lipgloss.Center).Render("No leaderboard entries found")
+	}
+
+	headerStyle := hintStyle.
+		Bold(true).
+		Align(lipgloss.Center)
+	rankStyle := lipgloss.NewStyle().Width(4).Align(lipgloss.Right)
+	nameStyle := lipgloss.NewStyle().Width(20).Align(lipgloss.Left)
+	numStyle := lipgloss.NewStyle().Width(8).Align(lipgloss.Right)
+
+	rows := []string{
+		lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			headerStyle.Copy().Inherit(rankStyle).Render("Rank"), "  ",
+			headerStyle.Copy().Inherit(nameStyle).Render("Player"), "  ",
+			headerStyle.Copy().Inherit(numStyle).Render("Tests"), "  ",
+			headerStyle.Copy().Inherit(numStyle).Render("Streak"),
+		),
+		mutedStyle.Render(strings.Repeat("─", 48)),
+	}
+
+	start, end := m.visibleRange()
+	for _, entry := range m.activeEntries[start:end] {
+		style := lipgloss.NewStyle()
+		if m.isAuthenticated && m.user != nil && entry.GitHubID == m.user.GitHubID {
+			style = warnStyle.Bold(true)
+		}
 
+		displayName := TruncateName(entry.Username, MaxNameWidth)
+
+		rows = append(rows, lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			style.Copy().Inherit(rankStyle).Render(fmt.Sprintf("#%d", entry.Rank)), "  ",
+			style.Copy().Inherit(nameStyle).Render(displayName), "  ",
+			style.Copy().Inherit(numStyle).Render(fmt.Sprintf("%d", entry.QualifiedScores)), "  ",
+			style.Copy().Inherit(numStyle).Render(fmt.Sprintf("%dd", entry.LongestStreak)),
+		))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
 
 func (m LeaderboardModel) renderInstructions() string {
 	var instructions []string
 
-	if m.isAuthenticated && m.user != nil {
-		welcomeMsg := fmt.Sprintf("Logged in as %s", m.user.Username)
+	if m.local {
+		instructions = append(instructions, mutedStyle.Render("Play with 'zt --name <nickname>' to join this board"))
+	} else if m.isAuthenticated && m.user != nil {
+		welcomeMsg := fmt.Sprintf("Logged in as %s", TruncateName(m.user.Username, 40))
 		instructions = append(instructions, 
-			lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render("✓ " + welcomeMsg))
+			goodStyle.Render("✓ " + welcomeMsg))
 	} else {
 		instructions = append(instructions, 
-			lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Render("⚠ Not authenticated - scores won't be saved"))
+			warnStyle.Render("⚠ Not authenticated - scores won't be saved"))
 		instructions = append(instructions, 
 			mutedStyle.Render("Use 'zentype auth' to authenticate with GitHub"))
 	}
 
+	if m.hidden > 0 {
+		instructions = append(instructions, mutedStyle.Render(
+			fmt.Sprintf("%d hidden player(s) • 'zt leaderboard --unhide <login>' to show them again", m.hidden)))
+	}
+
 	instructions = append(instructions, "")
-	instructions = append(instructions, mutedStyle.Render("Press 'r' to refresh • 'q' to quit"))
+	refresh, quit := controlKeyLabels(m.config)
+	scroll := ""
+	if m.pageSize() < m.rowCount() {
+		scroll = "↑/↓ to scroll • "
+	}
+	if m.refreshing {
+		instructions = append(instructions, mutedStyle.Render(fmt.Sprintf("Refreshing... • %s%s to quit", scroll, quit)))
+	} else if len(game.Languages) > 1 {
+		instructions = append(instructions, mutedStyle.Render(fmt.Sprintf("Press %s to refresh • %sTab to switch language • %s to quit", refresh, scroll, quit)))
+	} else {
+		instructions = append(instructions, mutedStyle.Render(fmt.Sprintf("Press %s to refresh • %s%s to quit", refresh, scroll, quit)))
+	}
 
     // Center the instructions across the full terminal width
     return lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(
@@ -289,11 +590,9 @@ func (m LeaderboardModel) renderInstructions() string {
 }
 
 func (m LeaderboardModel) renderLoading() string {
-	spinner := "⣾⣽⣻⢿⡿⣟⣯⣷"
-	frame := int(time.Now().UnixMilli()/100) % len(spinner)
 	content := lipgloss.JoinVertical(
 		lipgloss.Center,
-		lipgloss.NewStyle().Foreground(lipgloss.Color("12")).Render(string(spinner[frame])+" Loading leaderboard..."),
+		accentStyle.Render(spinnerFrames[m.spinnerFrame]+" Loading leaderboard..."),
 		"",
 		mutedStyle.Render("Fetching the latest rankings..."),
 	)
@@ -306,12 +605,33 @@ func (m LeaderboardModel) renderLoading() string {
 }
 
 func (m LeaderboardModel) renderError() string {
+	// Tell the user whether the server is down or just returned bad data
+	var diagnosis, hint string
+	if m.local {
+		diagnosis = "Couldn't read the local leaderboard"
+		hint = "Fix or remove ~/.zentype/local_board.json and try again"
+	} else if m.serverReachable {
+		diagnosis = "The server is reachable but returned an error"
+		hint = "This is likely a server-side problem; try again in a moment"
+	} else {
+		diagnosis = "Can't reach the ZenType server"
+		hint = "Check your internet connection and ZENTYPE_API_URL, or run 'zentype auth --status'"
+	}
+
+	title := "❌ Error Loading Leaderboard"
+	if m.failedAttempts > 1 {
+		title = fmt.Sprintf("❌ Error Loading Leaderboard (%d attempts)", m.failedAttempts)
+	}
+
 	content := lipgloss.JoinVertical(
 		lipgloss.Center,
-		lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true).Render("❌ Error Loading Leaderboard"),
+		alertStyle.Bold(true).Render(title),
 		"",
+		warnStyle.Render(diagnosis),
 		mutedStyle.Render(m.error),
 		"",
+		mutedStyle.Render(hint),
+		"",
 		mutedStyle.Copy().Align(lipgloss.Center).Render("Press 'r' to retry • 'q' to quit"),
 	)
 
@@ -325,17 +645,120 @@ func (m LeaderboardModel) renderError() string {
 // loadLeaderboard loads the leaderboard data
 func (m LeaderboardModel) loadLeaderboard() tea.Cmd {
 	return func() tea.Msg {
+		if m.local {
+			return loadLocalBoard(m.language)
+		}
+
 		// Ensure we have a valid client
 		if m.client == nil {
 			return loadErrorMsg{error: "API client not initialized"}
 		}
 		
-		response, err := m.client.GetLeaderboard(m.language)
+		if m.active {
+			entries, err := m.client.GetActiveLeaderboard(m.language, api.DefaultLeaderboardLimit)
+			if err != nil {
+				return loadErrorMsg{
+					error:           fmt.Sprintf("Failed to load leaderboard: %v", err),
+					serverReachable: m.client.CheckHealth() == nil,
+				}
+			}
+			return activeLoadedMsg{entries: entries}
+		}
+
+		response, err := m.client.GetLeaderboard(m.language, api.DefaultLeaderboardLimit)
 		if err != nil {
-			return loadErrorMsg{error: fmt.Sprintf("Failed to load leaderboard: %v", err)}
+			// Check connectivity separately to distinguish network from server errors
+			return loadErrorMsg{
+				error:           fmt.Sprintf("Failed to load leaderboard: %v", err),
+				serverReachable: m.client.CheckHealth() == nil,
+			}
 		}
 		return leaderboardLoadedMsg{entries: response.Entries, userEntry: response.UserEntry}
 	}
 }
 
+// nextLanguage returns the language after current in game.Languages, wrapping around
+func nextLanguage(current string) string {
+	for i, lang := range game.Languages {
+		if lang == current {
+			return game.Languages[(i+1)%len(game.Languages)]
+		}
+	}
+	return game.Languages[0]
+}
+
+// languageTitle capitalizes a language name for display (e.g. "english" -> "English")
+func languageTitle(language string) string {
+	first, size := utf8.DecodeRuneInString(language)
+	if size == 0 {
+		return ""
+	}
+	return string(unicode.ToTitle(first)) + language[size:]
+}
 
+// loadLocalBoard ranks the local nickname board in the shape of the global one
+// so it can share its table
+func loadLocalBoard(language string) tea.Msg {
+	store, err := localboard.NewStore()
+	if err != nil {
+		return loadErrorMsg{error: fmt.Sprintf("Failed to open local leaderboard: %v", err), serverReachable: true}
+	}
+	scores, err := store.Load()
+	if err != nil {
+		return loadErrorMsg{error: fmt.Sprintf("Failed to load local leaderboard: %v", err), serverReachable: true}
+	}
+
+	var entries []api.LeaderboardEntry
+	for i, score := range localboard.Best(scores, language) {
+		entries = append(entries, api.LeaderboardEntry{
+			Username:  score.Name,
+			WPM:       score.WPM,
+			Accuracy:  score.Accuracy,
+			Duration:  score.Duration,
+			Language:  score.Language,
+			CreatedAt: score.Timestamp,
+			Rank:      i + 1,
+		})
+	}
+	return leaderboardLoadedMsg{entries: entries}
+}
+
+// controlKeyLabels names the refresh and quit keys for the leaderboard and feed
+// instructions. 'q' quits too unless it was bound to another action.
+func controlKeyLabels(cfg *config.Config) (refresh, quit string) {
+	label := func(key string) string {
+		if len([]rune(key)) == 1 {
+			return "'" + key + "'"
+		}
+		return config.KeyLabel(key)
+	}
+	quit = "'q'"
+	if cfg.Action("q") != "" {
+		quit = label(cfg.Key(config.ActionQuit))
+	}
+	return label(cfg.Key(config.ActionRefresh)), quit
+}
+
+// formatAccuracy formats a leaderboard accuracy, which always shows at least
+// one decimal place because so many players are close to 100%
+func (m LeaderboardModel) formatAccuracy(accuracy float64) string {
+	return strconv.FormatFloat(accuracy, 'f', max(1, m.config.Decimals), 64) + "%"
+}
+
+// MaxNameWidth is the widest a player name is drawn in board tables, in
+// terminal cells, so the columns after it stay aligned
+const MaxNameWidth = 18
+
+// TruncateName fits a player name into width terminal cells, ending it with
+// "..." when it's cut. Width is measured per character rather than per byte,
+// so accented and wide (e.g. CJK) names are cut cleanly; control characters,
+// which would break the layout, are dropped.
+func TruncateName(name string, width int) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	return runewidth.Truncate(name, width, "...")
+}
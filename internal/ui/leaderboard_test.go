@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestTruncateName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		width int
+		want  string
+	}{
+		{name: "fits", input: "alice", width: 10, want: "alice"},
+		{name: "ascii cut", input: "abcdefghijkl", width: 8, want: "abcde..."},
+		// Each CJK character is two cells, so only two fit before the "..."
+		{name: "wide characters", input: "山田太郎さん", width: 8, want: "山田..."},
+		{name: "wide characters that fit", input: "山田太郎", width: 8, want: "山田太郎"},
+		// Combining accents take no cells and stay with their letter
+		{name: "combining marks fit", input: "e\u0301e\u0301e\u0301", width: 3, want: "e\u0301e\u0301e\u0301"},
+		{name: "combining marks cut", input: "re\u0301sume\u0301 writer", width: 8, want: "re\u0301sum..."},
+		{name: "control characters", input: "bad\x1b[31mname\n", width: 20, want: "bad[31mname"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateName(tt.input, tt.width)
+			if got != tt.want {
+				t.Errorf("TruncateName(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("TruncateName(%q, %d) = %q, not valid UTF-8", tt.input, tt.width, got)
+			}
+			if width := runewidth.StringWidth(got); width > tt.width {
+				t.Errorf("TruncateName(%q, %d) is %d cells wide", tt.input, tt.width, width)
+			}
+		})
+	}
+}
+
+func TestLanguageTitle(t *testing.T) {
+	tests := map[string]string{
+		"":        "",
+		"english": "English",
+		"español": "Español",
+		"élan":    "Élan",
+		"日本語":     "日本語",
+	}
+	for language, want := range tests {
+		if got := languageTitle(language); got != want {
+			t.Errorf("languageTitle(%q) = %q, want %q", language, got, want)
+		}
+	}
+}
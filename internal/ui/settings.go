@@ -0,0 +1,294 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/config"
+	"github.com/nemaniabhiram/zentype.cli/internal/game"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// setting is one editable line of the settings screen. Values are cycled in
+// order; get and set convert between the config and the displayed value.
+type setting struct {
+	label  string
+	values []string
+	get    func(*config.Config) string
+	set    func(*config.Config, string)
+}
+
+// onOff is the value list for boolean settings
+var onOff = []string{"off", "on"}
+
+func boolValue(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+// settings lists everything editable in 'zt settings', in display order
+var settings = []setting{
+	{
+		label:  "Default duration",
+		values: []string{"15", "30", "60", "120"},
+		get:    func(c *config.Config) string { return strconv.Itoa(c.Duration) },
+		set: func(c *config.Config, v string) {
+			c.Duration, _ = strconv.Atoi(v)
+		},
+	},
+	{
+		label:  "Language",
+		values: game.Languages,
+		get:    func(c *config.Config) string { return c.Language },
+		set:    func(c *config.Config, v string) { c.Language = v },
+	},
+	{
+		label:  "Word distribution",
+		values: game.WordDists,
+		get:    func(c *config.Config) string { return c.WordDist },
+		set:    func(c *config.Config, v string) { c.WordDist = v },
+	},
+	{
+		label:  "Blinking caret",
+		values: onOff,
+		get:    func(c *config.Config) string { return boolValue(c.Blink) },
+		set:    func(c *config.Config, v string) { c.Blink = v == "on" },
+	},
+	{
+		label:  "Focus mode",
+		values: onOff,
+		get:    func(c *config.Config) string { return boolValue(c.Focus) },
+		set:    func(c *config.Config, v string) { c.Focus = v == "on" },
+	},
+	{
+		label:  "Minimal mode",
+		values: onOff,
+		get:    func(c *config.Config) string { return boolValue(c.Minimal) },
+		set:    func(c *config.Config, v string) { c.Minimal = v == "on" },
+	},
+	{
+		label:  "Words left estimate",
+		values: onOff,
+		get:    func(c *config.Config) string { return boolValue(c.WordsLeft) },
+		set:    func(c *config.Config, v string) { c.WordsLeft = v == "on" },
+	},
+	{
+		label:  "Highlight recent word",
+		values: onOff,
+		get:    func(c *config.Config) string { return boolValue(c.RecentWord) },
+		set:    func(c *config.Config, v string) { c.RecentWord = v == "on" },
+	},
+	{
+		label:  "Theme",
+		values: ThemeNames,
+		get: func(c *config.Config) string {
+			if c.Theme == "" {
+				return DefaultTheme
+			}
+			return c.Theme
+		},
+		set: func(c *config.Config, v string) { c.Theme = v },
+	},
+	{
+		label:  "Live WPM",
+		values: onOff,
+		get:    func(c *config.Config) string { return boolValue(c.LiveWPM) },
+		set:    func(c *config.Config, v string) { c.LiveWPM = v == "on" },
+	},
+	{
+		label:  "Compare with average",
+		values: onOff,
+		get:    func(c *config.Config) string { return boolValue(c.CompareAverage) },
+		set:    func(c *config.Config, v string) { c.CompareAverage = v == "on" },
+	},
+	{
+		label:  "Enter on results",
+		values: []string{config.RestartNew, config.RestartSame},
+		get:    func(c *config.Config) string { return c.RestartMode },
+		set:    func(c *config.Config, v string) { c.RestartMode = v },
+	},
+	{
+		label:  "Redraw interval",
+		values: []string{"default", "2s", "5s"},
+		get: func(c *config.Config) string {
+			if c.RefreshMS == 0 {
+				return "default"
+			}
+			return fmt.Sprintf("%gs", float64(c.RefreshMS)/1000)
+		},
+		set: func(c *config.Config, v string) {
+			seconds, _ := strconv.ParseFloat(strings.TrimSuffix(v, "s"), 64)
+			c.RefreshMS = int(seconds * 1000)
+		},
+	},
+	{
+		label:  "Submit scores",
+		values: onOff,
+		get:    func(c *config.Config) string { return boolValue(c.SubmitScores) },
+		set:    func(c *config.Config, v string) { c.SubmitScores = v == "on" },
+	},
+	{
+		label:  "Submit only PBs",
+		values: onOff,
+		get:    func(c *config.Config) string { return boolValue(c.SubmitOnlyPB) },
+		set:    func(c *config.Config, v string) { c.SubmitOnlyPB = v == "on" },
+	},
+}
+
+// SettingsModel is a small form for editing ~/.zentype/config.json
+type SettingsModel struct {
+	width       int
+	height      int
+	config      *config.Config
+	cursor      int
+	dirty       bool   // Unsaved changes
+	confirmQuit bool   // Asked whether to save unsaved changes before quitting
+	status      string // Result of the last save
+	error       string
+}
+
+// NewSettingsModel creates the settings form from the current config
+func NewSettingsModel() *SettingsModel {
+	cfg, err := config.Load()
+	m := &SettingsModel{config: cfg}
+	if err != nil {
+		m.error = fmt.Sprintf("Couldn't read config, editing defaults: %v", err)
+	}
+	return m
+}
+
+// Init implements tea.Model
+func (m SettingsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update moves between settings, cycles their values and saves
+func (m SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.confirmQuit {
+			return m.answerQuit(msg.String())
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			if m.dirty {
+				m.confirmQuit = true
+				return m, nil
+			}
+			return m, tea.Quit
+		case "up", "k":
+			m.cursor = (m.cursor - 1 + len(settings)) % len(settings)
+		case "down", "j", "tab":
+			m.cursor = (m.cursor + 1) % len(settings)
+		case "right", "l", " ", "enter":
+			m.cycle(1)
+		case "left", "h":
+			m.cycle(-1)
+		case "s", "ctrl+s":
+			m.save()
+		}
+	}
+	return m, nil
+}
+
+// answerQuit handles the key pressed at the unsaved changes prompt: save and
+// quit, quit without saving, or go back to editing
+func (m SettingsModel) answerQuit(key string) (tea.Model, tea.Cmd) {
+	m.confirmQuit = false
+	switch key {
+	case "s", "ctrl+s", "y":
+		if m.save() {
+			return m, tea.Quit
+		}
+	case "d", "n", "q", "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// save writes the config, reporting whether it succeeded
+func (m *SettingsModel) save() bool {
+	if err := m.config.Save(); err != nil {
+		m.error = fmt.Sprintf("Failed to save: %v", err)
+		m.status = ""
+		return false
+	}
+	m.dirty = false
+	m.error = ""
+	m.status = "Saved"
+	return true
+}
+
+// cycle moves the selected setting to its next (step 1) or previous (step -1) value
+func (m *SettingsModel) cycle(step int) {
+	s := settings[m.cursor]
+	if len(s.values) < 2 {
+		return
+	}
+	// Values not in the list (e.g. a hand-edited duration) move to the first or last value
+	index := slices.Index(s.values, s.get(m.config))
+	if index < 0 {
+		index = len(s.values) - 1
+		if step < 0 {
+			index = 0
+		}
+	}
+	index = (index + step + len(s.values)) % len(s.values)
+	s.set(m.config, s.values[index])
+	m.dirty = true
+	m.status = ""
+}
+
+// View renders the settings form
+func (m SettingsModel) View() string {
+	title := accentStyle.
+		Bold(true).
+		Render("⚙ ZenType Settings")
+
+	labelStyle := lipgloss.NewStyle().Width(20)
+	selectedStyle := warnStyle.Bold(true)
+
+	rows := []string{title, mutedStyle.Render("Defaults for every test; command-line flags still override them"), ""}
+	for i, s := range settings {
+		value := fmt.Sprintf("‹ %s ›", s.get(m.config))
+		line := "  " + labelStyle.Render(s.label) + mutedStyle.Render(value)
+		if i == m.cursor {
+			line = selectedStyle.Render("▸ "+labelStyle.Render(s.label)) + selectedStyle.Render(value)
+		}
+		rows = append(rows, line)
+	}
+
+	rows = append(rows, "")
+	switch {
+	case m.confirmQuit:
+		rows = append(rows, warnStyle.Render("Save changes before quitting? s to save • d to discard • Esc to keep editing"))
+	case m.error != "":
+		rows = append(rows, alertStyle.Render(m.error))
+	case m.dirty:
+		rows = append(rows, warnStyle.Render("Unsaved changes"))
+	case m.status != "":
+		rows = append(rows, goodStyle.Render(m.status))
+	default:
+		rows = append(rows, "")
+	}
+	rows = append(rows, mutedStyle.Render("↑/↓ to select • ←/→ to change • s to save • q to quit"))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, rows...)
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+}
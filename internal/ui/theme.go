@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/config"
+	"github.com/nemaniabhiram/zentype.cli/internal/game"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is a color scheme for the typing and results screens
+type Theme struct {
+	Accent     lipgloss.TerminalColor // Timer and titles
+	Hint       lipgloss.TerminalColor // Tutorial tips and table headings
+	Muted      lipgloss.TerminalColor // Untyped text and labels
+	Error      lipgloss.TerminalColor // Mistakes, low accuracy and errors
+	Warn       lipgloss.TerminalColor // Middling accuracy, skipped characters, notices and your own rank
+	Good       lipgloss.TerminalColor // High accuracy and successes
+	Cursor     lipgloss.TerminalColor // Caret background
+	CursorText lipgloss.TerminalColor // Character under the caret
+	Dim        lipgloss.TerminalColor // Text outside the current word in focus mode
+	Corrected  lipgloss.TerminalColor // Characters fixed with backspace
+	Ghost      lipgloss.TerminalColor // Personal best marker
+
+	// ReverseCursor draws the caret in reverse video, for themes without colors
+	ReverseCursor bool
+}
+
+// DefaultTheme is used when no theme is configured
+const DefaultTheme = "default"
+
+// Themes are the built-in color schemes, chosen with --theme or the theme config key
+var Themes = map[string]Theme{
+	DefaultTheme: {
+		Accent: lipgloss.Color("12"), Hint: lipgloss.Color("14"), Muted: lipgloss.Color("8"), Error: lipgloss.Color("9"),
+		Warn: lipgloss.Color("11"), Good: lipgloss.Color("10"),
+		Cursor: lipgloss.Color("15"), CursorText: lipgloss.Color("#000"),
+		Dim: lipgloss.Color("237"), Corrected: lipgloss.Color("3"), Ghost: lipgloss.Color("5"),
+	},
+	// For terminals with a light background, where a white caret and
+	// near-black dimming disappear
+	"light": {
+		Accent: lipgloss.Color("4"), Hint: lipgloss.Color("6"), Muted: lipgloss.Color("245"), Error: lipgloss.Color("1"),
+		Warn: lipgloss.Color("130"), Good: lipgloss.Color("2"),
+		Cursor: lipgloss.Color("0"), CursorText: lipgloss.Color("15"),
+		Dim: lipgloss.Color("252"), Corrected: lipgloss.Color("130"), Ghost: lipgloss.Color("5"),
+	},
+	// Low-contrast blues and greens
+	"ocean": {
+		Accent: lipgloss.Color("39"), Hint: lipgloss.Color("87"), Muted: lipgloss.Color("67"), Error: lipgloss.Color("203"),
+		Warn: lipgloss.Color("221"), Good: lipgloss.Color("79"),
+		Cursor: lipgloss.Color("117"), CursorText: lipgloss.Color("17"),
+		Dim: lipgloss.Color("24"), Corrected: lipgloss.Color("180"), Ghost: lipgloss.Color("141"),
+	},
+	// No colors at all; mistakes stay underlined and the caret is reversed
+	"mono": {
+		Accent: lipgloss.NoColor{}, Hint: lipgloss.NoColor{}, Muted: lipgloss.NoColor{}, Error: lipgloss.NoColor{},
+		Warn: lipgloss.NoColor{}, Good: lipgloss.NoColor{},
+		Cursor: lipgloss.NoColor{}, CursorText: lipgloss.NoColor{},
+		Dim: lipgloss.NoColor{}, Corrected: lipgloss.NoColor{}, Ghost: lipgloss.NoColor{},
+		ReverseCursor: true,
+	},
+}
+
+// currentTheme is the theme last applied with ApplyTheme
+var currentTheme = Themes[DefaultTheme]
+
+// CurrentTheme returns the theme in use, for output drawn outside the ui
+// package's own styles
+func CurrentTheme() Theme {
+	return currentTheme
+}
+
+// ThemeNames lists the built-in themes in the order they are shown
+var ThemeNames = []string{DefaultTheme, "light", "ocean", "mono"}
+
+// ApplyTheme restyles every screen with the named theme; an empty name is the default
+func ApplyTheme(name string) error {
+	if name == "" {
+		name = DefaultTheme
+	}
+	theme, ok := Themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q: use %s", name, strings.Join(ThemeNames, ", "))
+	}
+
+	currentTheme = theme
+	timeStyle = timeStyle.Foreground(theme.Accent)
+	mutedStyle = mutedStyle.Foreground(theme.Muted)
+	errorStyle = errorStyle.Foreground(theme.Error)
+	cursorStyle = cursorStyle.Background(theme.Cursor).Foreground(theme.CursorText).Reverse(theme.ReverseCursor)
+	accuracyLowStyle = accuracyLowStyle.Foreground(theme.Error)
+	accuracyMidStyle = accuracyMidStyle.Foreground(theme.Warn)
+	accuracyHighStyle = accuracyHighStyle.Foreground(theme.Good)
+	focusDimStyle = focusDimStyle.Foreground(theme.Dim)
+	correctedStyle = correctedStyle.Foreground(theme.Corrected)
+	ghostStyle = ghostStyle.Foreground(theme.Ghost)
+	missingStyle = missingStyle.Foreground(theme.Warn)
+	extraStyle = extraStyle.Foreground(theme.Error)
+	accentStyle = accentStyle.Foreground(theme.Accent)
+	hintStyle = hintStyle.Foreground(theme.Hint)
+	warnStyle = warnStyle.Foreground(theme.Warn)
+	goodStyle = goodStyle.Foreground(theme.Good)
+	alertStyle = alertStyle.Foreground(theme.Error)
+	return nil
+}
+
+// previewText is typed by the theme preview; it has enough words for a game
+const previewText = "the quick brown fox jumps over the lazy dog while five boxing wizards " +
+	"jump quickly and a wizard's job is to vex chumps quickly in fog as we " +
+	"promptly judged antique ivory buckles for the next prize"
+
+// previewTyped is the preview's input, with a few mistakes so every style shows
+const previewTyped = "the quick bxown fox jumps ovex the la"
+
+// previewStats are the canned results shown by the theme preview
+var previewStats = game.TypingStats{
+	WPM:             87.4,
+	Accuracy:        96.2,
+	TimeElapsed:     60 * time.Second,
+	IsComplete:      true,
+	ErrorsPerMinute: 3,
+	AccuracyModel:   game.AccuracyStandard,
+}
+
+// RenderThemePreview draws a sample typing screen and results screen in the
+// named theme, using canned input instead of a real test
+func RenderThemePreview(name string) (string, error) {
+	if err := ApplyTheme(name); err != nil {
+		return "", err
+	}
+
+	start := time.Now()
+	now := start
+	typingGame, err := game.NewTypingGameWithWords(60, strings.Fields(previewText))
+	if err != nil {
+		return "", err
+	}
+	typingGame.SetClock(func() time.Time { return now })
+	typingGame.SetGenerator(func(int) []string { return strings.Fields(previewText) })
+	for i, char := range previewTyped {
+		now = start.Add(time.Duration(i) * 180 * time.Millisecond)
+		typingGame.AddCharacter(char)
+	}
+
+	m := Model{
+		game:     typingGame,
+		duration: 60,
+		language: "english",
+		config:   config.Default(),
+		opts:     Options{ShowCorrections: true},
+	}
+	typing := m.View()
+
+	m.finalStats = previewStats
+	m.showResults = true
+	m.userRank = 42
+	m.submittedWPM = m.finalStats.WPM
+	results := m.View()
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		boldStyle.Render(fmt.Sprintf("Theme: %s", name)), "",
+		mutedStyle.Render("Typing screen"), typing, "",
+		mutedStyle.Render("Results screen"), results,
+	), nil
+}
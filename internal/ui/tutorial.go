@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/config"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TutorialDuration is the length of the guided test in 'zt tutorial'
+const TutorialDuration = 15
+
+// tutorialHint is the guidance shown under the text during the tutorial test,
+// following what the user is doing
+func (m Model) tutorialHint() string {
+	switch {
+	case !m.game.IsStarted:
+		return "Type the words above. The timer starts with your first key."
+	case len(m.game.Errors) > 0:
+		return "Red letters are mistakes. Backspace fixes them, even on the line before."
+	default:
+		return "The number above counts down the seconds left. Keep going!"
+	}
+}
+
+// tutorialResultsGuide explains the results screen after the tutorial test
+func (m Model) tutorialResultsGuide() string {
+	// Every tab after the summary, by the names shown in the tab row
+	others := resultsViewNames[1:]
+	tabs := fmt.Sprintf("Tab or ←/→ to see the other views: %s and %s",
+		strings.Join(others[:len(others)-1], ", "), others[len(others)-1])
+
+	lines := []string{
+		"wpm is your speed: every 5 characters typed count as one word",
+		"acc is the share of characters you typed correctly",
+		tabs,
+		"Press " + m.keyLabel(config.ActionQuit) + " when you're done looking around",
+	}
+	for i, line := range lines {
+		lines[i] = hintStyle.Render("• " + line)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -2,15 +2,23 @@ package ui
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/nemaniabhiram/zentype.cli/internal/game"
 	"github.com/nemaniabhiram/zentype.cli/internal/api"
 	"github.com/nemaniabhiram/zentype.cli/internal/auth"
+	"github.com/nemaniabhiram/zentype.cli/internal/config"
+	"github.com/nemaniabhiram/zentype.cli/internal/history"
+	"github.com/nemaniabhiram/zentype.cli/internal/localboard"
+	"github.com/nemaniabhiram/zentype.cli/internal/pending"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 )
 
 const statGap = 5
@@ -33,6 +41,9 @@ var (
 	boldStyle = lipgloss.NewStyle().
 			Bold(true)
 
+	// Words typed before the most recent one, when recent_word is on
+	settledStyle = lipgloss.NewStyle()
+
 	mutedStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("8"))
 
@@ -46,11 +57,134 @@ var (
 			Foreground(lipgloss.Color("#000")).
 			Bold(true)
 
+	// Accuracy bands on the results screen
+	accuracyLowStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("9")).
+				Bold(true)
+
+	accuracyMidStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("11")).
+				Bold(true)
+
+	accuracyHighStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("10")).
+				Bold(true)
+
+	focusDimStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("237"))
+
+	// Characters that were mistyped, backspaced and then typed correctly
+	correctedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("3"))
+
+	// Where a typist at your personal best pace would be
+	ghostStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("5")).
+			Underline(true)
+
+	// Replay view: expected characters that were skipped, and typed characters
+	// that aren't in the text
+	missingStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("11")).
+			Underline(true)
+
+	extraStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("9")).
+			Strikethrough(true)
+
 	resultsContainerStyle = lipgloss.NewStyle().
 				Padding(3, 5).
 				Align(lipgloss.Left)
+
+	// Theme colors for titles, highlights and messages on every screen
+	accentStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("12"))
+	hintStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
+	warnStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	goodStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	alertStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
 )
 
+// Options holds optional behaviour toggles for a typing test
+type Options struct {
+	Focus     bool          // Dim everything except the current word
+	Blink     bool          // Blink the caret
+	BlinkRate time.Duration // Interval between caret blinks (defaults to DefaultBlinkRate)
+	NoSubmit  bool          // Never submit scores for this run
+	Generator game.WordGenerator // Custom word source; defaults to the seeded language word list
+	ShowCorrections bool    // Style corrected characters differently from clean ones
+	SpaceGlyph string       // Visible stand-in for spaces; empty renders them blank
+	Quiet     bool          // Exit as soon as the test ends instead of showing results
+	Seed      int64         // Non-zero draws the same words from this seed on every restart
+	ChallengeDate string    // Set for the daily challenge; scores go to that date's board
+	CountCorrections bool   // Also show raw WPM/accuracy counting every keystroke
+	LoopDelay time.Duration // Start a new test this long after results; 0 disables looping
+	Notice    string        // Shown under the text until the user starts typing
+	CaretScroll bool        // Move the caret down the lines instead of scrolling every line
+	Minimal   bool          // Hide the timer during the test; stats appear only on the results screen
+	Ghost     bool          // Show a marker racing at your personal best pace
+	RestartMode string      // config.RestartNew or config.RestartSame; empty uses the config file
+	WordDist  string        // game.DistUniform (default) or game.DistFrequency
+	AccuracyModel string    // One of game.AccuracyModels; empty means game.AccuracyStandard
+	UntilWPM  float64       // Run open-ended until recent WPM holds this for game.SustainWindow; 0 disables
+	Refresh   time.Duration // Shortest interval between redraws of the timer, ghost and caret; 0 uses their own rates
+	LocalName string        // Nickname to record results under on the local leaderboard; empty skips it
+	WordsLeft bool          // Show an estimate of the words left next to the timer
+	CompareAverage bool     // Compare submitted results with the global averages
+	Review    bool          // Pause briefly on every new mistake, with the clock stopped
+	LiveWPM   bool          // Show a smoothed live WPM counter next to the timer
+	Tutorial  bool          // Show guidance for first-time users during the test and on the results screen
+	Adaptive  Adaptive      // Calibration behind --adaptive practice; zero otherwise
+}
+
+// Adaptive is the baseline measured by an --adaptive calibration test and the
+// word difficulty it chose, kept for the whole session
+type Adaptive struct {
+	Baseline   float64 // WPM of the calibration test
+	Difficulty string  // One of game.Difficulties; empty when not adaptive
+}
+
+// String describes the calibration for notices and the results screen
+func (a Adaptive) String() string {
+	return fmt.Sprintf("Adaptive: baseline %.0f WPM, %s words", a.Baseline, a.Difficulty)
+}
+
+// ScrollModes maps the --scroll values to whether the caret moves down the lines
+var ScrollModes = map[string]bool{
+	"line":  false,
+	"caret": true,
+}
+
+// SpaceGlyphs maps the --show-spaces styles to the glyph drawn for a space
+var SpaceGlyphs = map[string]string{
+	"blank":      "",
+	"dot":        "·",
+	"underscore": "_",
+}
+
+// RankedDuration is the only test length, in seconds, submitted to the leaderboard
+const RankedDuration = 60
+
+// RankedMinAccuracy is the accuracy, in percent, a test needs to be ranked
+const RankedMinAccuracy = 85.0
+
+// GlobalStatsTTL is how long fetched global averages are reused, so looping or
+// restarting tests doesn't cost an extra request each time
+const GlobalStatsTTL = 5 * time.Minute
+
+// SubmitSlowAfter is how long a score submission runs before the results
+// screen says the server is slow and how to quit without waiting
+const SubmitSlowAfter = 5 * time.Second
+
+// ReviewPause is how long --review stops on each new mistake
+const ReviewPause = 1200 * time.Millisecond
+
+// MaxKeyBurst is the most characters one key message may carry and still count
+// as typing, e.g. a word committed by an input method; longer bursts are pastes
+const MaxKeyBurst = 8
+
+// DefaultBlinkRate is the caret blink interval used when none is configured
+const DefaultBlinkRate = 530 * time.Millisecond
+
 // Model represents the state of the typing test application
 type Model struct {
 	game        *game.TypingGame
@@ -64,69 +198,305 @@ type Model struct {
 	authManager *auth.Manager
 	userRank    int
 	submitting  bool
+	submitStarted time.Time // When the current submission began
+	submitID    int         // Identifies the current submission so stale ticks are ignored
 	submitError string
+	submitQueued bool
 	isAuthenticated bool
+	notice      string
+	personalBest bool
+	pbGain      float64
+	opts        Options
+	caretHidden bool
+	resultsView resultsView
+	config      *config.Config
+	submittedWPM float64
+	rankGap     float64 // WPM needed to pass the user ranked immediately above
+	knownBest   float64 // User's best WPM, fetched at startup when submit_only_pb is set
+	skippedNotPB bool
+	generate    game.WordGenerator // Word source of the current game
+	seed        int64 // Seed of the current game's words; 0 for custom generators
+	loopRemaining int // Seconds until the next looped test; 0 when no countdown is running
+	loopID      int   // Identifies the current countdown so stale ticks are ignored
+	ghostWPM    float64 // Pace of the ghost marker; 0 hides it
+	targetReached bool  // The --until-wpm target was sustained, ending the test
+	globalStats   *api.GlobalStats // Averages of all players for --compare-average; kept across restarts
+	globalStatsAt time.Time        // When globalStats was fetched
+	liveWPM       game.WPMSmoother // Smoothed live WPM shown with --live-wpm
+	reviewing     bool // Paused on a mistake in --review mode; typing is ignored
+	reviewID      int  // Identifies the current review pause so stale messages are ignored
 }
 
+// resultsView selects which breakdown the results screen shows
+type resultsView int
+
+const (
+	resultsSummary resultsView = iota
+	resultsSlowestWords
+	resultsFingers
+	resultsLatency
+	resultsReplay
+	resultsViewCount
+)
+
+// resultsViewNames labels the results tabs, indexed by resultsView
+var resultsViewNames = []string{"summary", "slowest words", "fingers", "latency", "replay"}
+
 // tickMsg is a message type used to handle periodic updates in the application
 type tickMsg time.Time
 
+// blinkMsg toggles the caret when blinking is enabled
+type blinkMsg time.Time
+
+// ghostMsg redraws the text so the ghost marker advances smoothly
+type ghostMsg time.Time
+
+// loopTickMsg counts down to the next test in --loop mode
+type loopTickMsg struct {
+	id int
+}
+
+// reviewDoneMsg ends a --review pause
+type reviewDoneMsg struct {
+	id int
+}
+
+// submitTickMsg redraws the elapsed time of a submission in progress
+type submitTickMsg struct {
+	id int
+}
+
 // Message types for API operations
 type scoreSubmittedMsg struct {
-	entry *api.LeaderboardEntry
+	entry   *api.LeaderboardEntry
+	rankGap float64
 }
 
 type submitErrorMsg struct {
-	error string
+	error  string
+	queued bool // The server was unreachable and the score was saved to retry later
+}
+
+// knownBestMsg carries the user's current best WPM for submit_only_pb
+type knownBestMsg struct {
+	bestWPM float64
+}
+
+// globalStatsMsg carries the global averages for --compare-average
+type globalStatsMsg struct {
+	stats *api.GlobalStats
 }
 
 type userRankMsg struct {
-    rank int
+    rank    int
+    rankGap float64
 }
 
 // NewModel initializes a new Model instance with the specified duration and language
-func NewModel(duration int, language string) *Model {
+func NewModel(duration int, language string) (*Model, error) {
+	return NewModelWithOptions(duration, language, Options{})
+}
+
+// NewModelWithOptions initializes a new Model instance with optional behaviour toggles
+func NewModelWithOptions(duration int, language string, opts Options) (*Model, error) {
+	if opts.Blink && opts.BlinkRate <= 0 {
+		opts.BlinkRate = DefaultBlinkRate
+	}
+
+	// A config opt-out applies to every run; --no-submit only to this one
+	cfg, _ := config.Load()
+	if !cfg.SubmitScores {
+		opts.NoSubmit = true
+	}
+	if opts.RestartMode == "" {
+		opts.RestartMode = cfg.RestartMode
+	}
+	if opts.Refresh == 0 {
+		opts.Refresh = time.Duration(cfg.RefreshMS) * time.Millisecond
+	}
+	if opts.Blink && opts.BlinkRate < opts.Refresh {
+		opts.BlinkRate = opts.Refresh
+	}
+	// The leaderboard compares standard accuracy, so other models are practice only
+	if opts.AccuracyModel != "" && opts.AccuracyModel != game.AccuracyStandard {
+		opts.NoSubmit = true
+	}
+
 	client := api.NewClient()
 	authManager, _ := auth.NewManager(client)
 	
 	// Cache authentication status to avoid HTTP requests during rendering
 	isAuthenticated := authManager.IsAuthenticated()
+
+	generate, seed := wordSource(opts)
+	typingGame, err := game.NewTypingGameWithGenerator(duration, generate)
+	if err != nil {
+		return nil, err
+	}
+	typingGame.CaretScroll = opts.CaretScroll
+	typingGame.AccuracyModel = opts.AccuracyModel
 	
-	return &Model{
-		game:            game.NewTypingGame(duration),
+	model := &Model{
+		game:            typingGame,
 		duration:        duration,
 		language:        language,
 		client:          client,
 		authManager:     authManager,
 		isAuthenticated: isAuthenticated,
+		opts:            opts,
+		config:          cfg,
+		generate:        generate,
+		seed:            seed,
+		notice:          opts.Notice,
+		liveWPM:         game.WPMSmoother{Smoothing: cfg.WPMSmoothing},
+	}
+	if err := cfg.KeysError(); err != nil && model.notice == "" {
+		model.notice = err.Error()
+	}
+	model.ghostWPM = model.ghostPace()
+	if opts.Ghost && model.ghostWPM == 0 && model.notice == "" {
+		model.notice = "No personal best for this test yet — the ghost appears once you have one"
+	}
+	return model, nil
+}
+
+// wordSource returns a fresh word generator for a new game and the seed it was
+// built from. Language tests are always seeded so any run can be replayed: a
+// fixed opts.Seed repeats the same words on every restart, otherwise each game
+// gets a new random seed. Custom generators (e.g. drills) have no seed (0).
+func wordSource(opts Options) (game.WordGenerator, int64) {
+	if opts.Generator != nil {
+		return opts.Generator, 0
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
 	}
+	return game.SeededWordsWithDist(seed, opts.WordDist), seed
 }
 
 // restartTest resets the game state for a new typing test session
 func (m *Model) restartTest() {
-	m.game = game.NewTypingGame(m.duration)
+	generate, seed := wordSource(m.opts)
+	typingGame, err := game.NewTypingGameWithGenerator(m.duration, generate)
+	if err != nil {
+		m.notice = err.Error()
+		return
+	}
+	typingGame.CaretScroll = m.opts.CaretScroll
+	typingGame.AccuracyModel = m.opts.AccuracyModel
+	m.game = typingGame
+	m.generate = generate
+	m.seed = seed
+	m.resetResults()
+}
+
+// restartFromResults starts the next test from the results screen, with new
+// or the same words depending on the restart mode
+func (m *Model) restartFromResults() {
+	if m.opts.RestartMode != config.RestartSame {
+		m.restartTest()
+		return
+	}
+	m.restartCurrentTest()
+	m.resetResults()
+}
+
+// resetResults clears everything about the finished test before the next one
+func (m *Model) resetResults() {
 	m.showResults = false
 	m.finalStats = game.TypingStats{}
 	m.userRank = 0
 	m.submitting = false
+	m.submitID++
 	m.submitError = ""
+	m.submitQueued = false
+	m.liveWPM = game.WPMSmoother{Smoothing: m.config.WPMSmoothing}
+	m.notice = ""
+	m.personalBest = false
+	m.pbGain = 0
+	m.resultsView = resultsSummary
+	m.submittedWPM = 0
+	m.rankGap = 0
+	m.skippedNotPB = false
+	m.ghostWPM = m.ghostPace()
+	m.targetReached = false
+	m.reviewing = false
 }
 
 // restartCurrentTest resets the current test with the same words
 func (m *Model) restartCurrentTest() {
 	// Keep the same words but reset game state
 	words := m.game.AllWords
-	m.game = game.NewTypingGameWithWords(m.duration, words)
+	typingGame, err := game.NewTypingGameWithWords(m.duration, words)
+	if err != nil {
+		m.notice = err.Error()
+		return
+	}
+	typingGame.SetGenerator(m.generate)
+	typingGame.CaretScroll = m.opts.CaretScroll
+	typingGame.AccuracyModel = m.opts.AccuracyModel
+	m.game = typingGame
+	m.notice = ""
+	m.reviewing = false
 }
 
 // Init initializes the model and starts the tick command for periodic updates
 func (m Model) Init() tea.Cmd {
-	return tickCmd()
+	cmds := []tea.Cmd{m.tickCmd()}
+	if m.opts.Blink {
+		cmds = append(cmds, blinkCmd(m.opts.BlinkRate))
+	}
+	if m.opts.Ghost {
+		cmds = append(cmds, m.ghostCmd())
+	}
+	if m.config.SubmitOnlyPB && m.isAuthenticated && !m.opts.NoSubmit {
+		cmds = append(cmds, m.knownBestCmd())
+	}
+	return tea.Batch(cmds...)
+}
+
+// GhostRefresh is how often the ghost marker moves; at 100 WPM it covers ~1 character per refresh
+const GhostRefresh = 120 * time.Millisecond
+
+// ghostCmd returns a command that redraws the ghost marker after GhostRefresh,
+// or the configured refresh interval if that is longer
+func (m Model) ghostCmd() tea.Cmd {
+	return tea.Tick(max(GhostRefresh, m.opts.Refresh), func(t time.Time) tea.Msg {
+		return ghostMsg(t)
+	})
 }
 
-// tickCmd returns a command that sends a tick message every 1 second
-func tickCmd() tea.Cmd {
-	return tea.Tick(1*time.Second, func(t time.Time) tea.Msg {
+// blinkCmd returns a command that sends a blink message after the given interval
+func blinkCmd(rate time.Duration) tea.Cmd {
+	return tea.Tick(rate, func(t time.Time) tea.Msg {
+		return blinkMsg(t)
+	})
+}
+
+// loopTickCmd returns a command that counts down one second of the --loop delay
+func loopTickCmd(id int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return loopTickMsg{id: id}
+	})
+}
+
+// submitTickCmd returns a command that ticks once a second while a score is submitted
+func submitTickCmd(id int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return submitTickMsg{id: id}
+	})
+}
+
+// tickCmd returns a command that sends a tick message every second, or every
+// refresh interval if that is longer. The tick is brought forward to the end of
+// a timed test so the test always finishes on time.
+func (m Model) tickCmd() tea.Cmd {
+	interval := max(time.Second, m.opts.Refresh)
+	if left, ok := m.game.TimeLeft(); ok && left > 0 && left < interval {
+		interval = left
+	}
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
@@ -142,19 +512,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Handle keyboard input and game logic
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "esc":
+		// Always show the caret right after a keypress so blinking never hides input feedback
+		m.caretHidden = false
+
+		// Any key on the results screen cancels a pending loop restart
+		m.loopRemaining = 0
+
+		// Control actions come first so they can be moved onto any key
+		switch m.config.Action(msg.String()) {
+		case config.ActionQuit:
 			return m, tea.Quit
 
-		case "enter":
+		case config.ActionRestart:
 			if m.showResults {
-				m.restartTest()
-				return m, tickCmd()
+				m.restartFromResults()
+				return m, m.tickCmd()
 			}
 			// If game has started, restart current test
 			if m.game.IsStarted {
 				m.restartCurrentTest()
-				return m, tickCmd()
+				return m, m.tickCmd()
 			}
 			// Handle Enter for line progression if no input yet
 			if m.game.HandleEnterKey() {
@@ -162,88 +539,327 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case config.ActionNewWords:
+			if m.showResults || m.game.IsStarted {
+				m.restartTest()
+				return m, m.tickCmd()
+			}
+			return m, nil
+
+		case config.ActionFinish:
+			// Open-ended tests have no timer, so the user ends them manually
+			if !m.showResults && m.game.IsOpenEnded() && m.game.IsStarted {
+				m.game.Finish()
+				return m, m.finishTest()
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+
 		case " ":
-			if !m.showResults && !m.game.IsFinished && !m.game.IsTimeUp() {
-				m.game.AddCharacter(' ')
+			if !m.showResults && !m.game.IsFinished && !m.game.IsTimeUp() && !m.reviewing {
+				return m, m.typeChar(' ')
+			}
+			return m, nil
+
+		case "tab", "right":
+			// Cycle through the results views
+			if m.showResults {
+				m.resultsView = (m.resultsView + 1) % resultsViewCount
+			}
+			return m, nil
+
+		case "shift+tab", "left":
+			if m.showResults {
+				m.resultsView = (m.resultsView + resultsViewCount - 1) % resultsViewCount
 			}
 			return m, nil
 
 		case "backspace":
-			if !m.showResults && !m.game.IsFinished {
+			if !m.showResults && !m.game.IsFinished && !m.reviewing {
 				m.game.RemoveCharacter()
 			}
 			return m, nil
 
 		default:
+			// Number keys jump straight to a results view
+			if m.showResults {
+				if key := msg.String(); len(key) == 1 && key[0] >= '1' && int(key[0]-'1') < int(resultsViewCount) {
+					m.resultsView = resultsView(key[0] - '1')
+				}
+				return m, nil
+			}
+
 			// Handle regular character input
-			if !m.showResults && !m.game.IsFinished && !m.game.IsTimeUp() {
-				runes := []rune(msg.String())
-				if len(runes) == 1 && runes[0] >= 32 && runes[0] <= 126 {
-					m.game.AddCharacter(runes[0])
+			if !m.showResults && !m.game.IsFinished && !m.game.IsTimeUp() && !m.reviewing {
+				// Pasted text (bracketed paste or a long burst) is rejected
+				// on purpose: feeding it through AddCharacter would let a test be
+				// completed without typing, so we tell the user instead of
+				// silently dropping it.
+				if msg.Paste || len(msg.Runes) > MaxKeyBurst {
+					m.notice = "Pasting is disabled during a test"
+					return m, nil
+				}
+				if msg.Type != tea.KeyRunes || msg.Alt {
+					return m, nil
 				}
+				runes := msg.Runes
+				for _, char := range runes {
+					if !unicode.IsPrint(char) {
+						return m, nil
+					}
+				}
+
+				// A short burst, e.g. from an input method, is typed one character at
+				// a time until a --review pause stops it
+				m.notice = ""
+				var cmd tea.Cmd
+				for _, char := range runes {
+					if m.reviewing || m.game.IsFinished {
+						break
+					}
+					cmd = m.typeChar(char)
+				}
+				return m, cmd
 			}
 			return m, nil
 		}
 
+	case loopTickMsg:
+		if msg.id != m.loopID || m.loopRemaining == 0 || !m.showResults {
+			return m, nil
+		}
+		m.loopRemaining--
+		if m.loopRemaining == 0 {
+			m.restartFromResults()
+			return m, m.tickCmd()
+		}
+		return m, loopTickCmd(m.loopID)
+
+	case reviewDoneMsg:
+		if msg.id != m.reviewID || !m.reviewing {
+			return m, nil
+		}
+		m.reviewing = false
+		m.game.Resume()
+		return m, nil
+
+	case submitTickMsg:
+		if msg.id != m.submitID || !m.submitting || !m.showResults {
+			return m, nil
+		}
+		return m, submitTickCmd(m.submitID)
+
+	// Toggle the caret for blinking; the loop runs for the lifetime of the program
+	case blinkMsg:
+		m.caretHidden = !m.caretHidden
+		return m, blinkCmd(m.opts.BlinkRate)
+
+	// Nothing to update: receiving the message re-renders the ghost at its new position
+	case ghostMsg:
+		return m, m.ghostCmd()
+
 	// Handle tick messages for periodic updates
 	case tickMsg:
 		if !m.showResults {
 			if m.game.IsTimeUp() && m.game.IsStarted {
-				m.finalStats = m.game.GetStats()
-				m.showResults = true
-				
-				// Submit score if authenticated and 60-second test
-				if m.isAuthenticated && m.duration == 60 && !m.submitting {
-					m.submitting = true
-					return m, m.submitScore()
+				return m, m.finishTest()
+			}
+			if m.targetSustained() {
+				m.game.Finish()
+				m.targetReached = true
+				return m, m.finishTest()
+			}
+			if m.opts.LiveWPM {
+				if wpm, ok := m.game.LiveWPM(); ok {
+					m.liveWPM.Update(m.game.Elapsed(), wpm)
 				}
-				
-				return m, nil
 			}
-			return m, tickCmd()
+			return m, m.tickCmd()
 		}
 		return m, nil
 
 	// Handle score submission results
 	case scoreSubmittedMsg:
         m.submitting = false
+        // The leaderboard ranks gross WPM; remember exactly what was sent
+        m.submittedWPM = m.finalStats.WPM
+        if m.opts.ChallengeDate == "" && m.submittedWPM > m.knownBest {
+            m.knownBest = m.submittedWPM
+        }
         if msg.entry != nil {
             m.userRank = msg.entry.Rank
+            m.personalBest = msg.entry.PersonalBest && m.qualifies()
+            if m.personalBest && msg.entry.PreviousBest > 0 {
+                m.pbGain = msg.entry.WPM - msg.entry.PreviousBest
+            }
         }
-        if m.userRank == 0 {
-            return m, m.getRankCmd()
+        m.rankGap = msg.rankGap
+        var cmds []tea.Cmd
+        if m.userRank == 0 && m.opts.ChallengeDate == "" {
+            cmds = append(cmds, m.getRankCmd())
         }
-        return m, nil
+        if m.opts.CompareAverage && m.opts.ChallengeDate == "" && time.Since(m.globalStatsAt) > GlobalStatsTTL {
+            cmds = append(cmds, m.globalStatsCmd())
+        }
+        return m, tea.Batch(cmds...)
+
+	case globalStatsMsg:
+		if msg.stats != nil {
+			m.globalStats = msg.stats
+			m.globalStatsAt = time.Now()
+		}
+		return m, nil
+
+	case knownBestMsg:
+		m.knownBest = msg.bestWPM
+		return m, nil
 
 	case userRankMsg:
         if msg.rank > 0 {
             m.userRank = msg.rank
+            m.rankGap = msg.rankGap
         }
         return m, nil
 
     case submitErrorMsg:
 		m.submitting = false
 		m.submitError = msg.error
+		m.submitQueued = msg.queued
 		return m, nil
 	}
 
 	return m, nil
 }
 
+// typeChar types one character and, in --review mode, pauses the test for
+// ReviewPause if it was a new mistake
+func (m *Model) typeChar(char rune) tea.Cmd {
+	errors := m.game.TotalErrorsMade
+	m.game.AddCharacter(char)
+	if !m.opts.Review || m.game.TotalErrorsMade == errors {
+		return nil
+	}
+
+	m.game.Pause()
+	m.reviewing = true
+	m.reviewID++
+	id := m.reviewID
+	return tea.Tick(ReviewPause, func(time.Time) tea.Msg {
+		return reviewDoneMsg{id: id}
+	})
+}
+
+// renderReview describes the mistake --review has paused on
+func (m Model) renderReview() string {
+	if len(m.game.KeyLog) == 0 {
+		return ""
+	}
+	key := m.game.KeyLog[len(m.game.KeyLog)-1]
+	return fmt.Sprintf("✗ expected '%s', typed '%s'", visibleRune(key.Expected), visibleRune(key.Char))
+}
+
+// finishTest captures the final stats, shows the results screen and
+// returns the score submission command when the run is eligible
+func (m *Model) finishTest() tea.Cmd {
+	m.finalStats = m.game.GetStats()
+	m.showResults = true
+
+	// Results spoiled by a clock jump are shown but never kept
+	valid := m.finalStats.Invalid == ""
+	// Review pauses stop the clock, so the WPM would skew history, the local
+	// board and the ghost's personal best
+	if valid && !m.opts.Review {
+		m.recordHistory()
+		m.recordLocalScore()
+	}
+
+	// Submit score if authenticated and 60-second test, unless the user opted out
+	var submit tea.Cmd
+	if valid && m.isAuthenticated && m.qualifies() && !m.submitting && !m.opts.NoSubmit {
+		// The known best is for the standard board, so daily runs always submit
+		if m.config.SubmitOnlyPB && m.opts.ChallengeDate == "" && m.knownBest > 0 && m.finalStats.WPM <= m.knownBest {
+			m.skippedNotPB = true
+		} else {
+			m.submitting = true
+			m.submitStarted = time.Now()
+			m.submitID++
+			submit = m.submitScore()
+		}
+	}
+
+	// Quiet runs exit straight away, once any submission has finished
+	if m.opts.Quiet {
+		return tea.Sequence(submit, tea.Quit)
+	}
+
+	// Count the seconds while the submission is in flight
+	if m.submitting {
+		submit = tea.Batch(submit, submitTickCmd(m.submitID))
+	}
+
+	// Looping shows results for a while, then starts the next test
+	if m.opts.LoopDelay > 0 {
+		m.loopID++
+		m.loopRemaining = int(math.Ceil(m.opts.LoopDelay.Seconds()))
+		return tea.Batch(submit, loopTickCmd(m.loopID))
+	}
+	return submit
+}
+
+// FinalStats returns the stats of the completed test, and false if the
+// program exited before a test finished
+func (m Model) FinalStats() (game.TypingStats, bool) {
+	return m.finalStats, m.showResults
+}
+
+// ReachedWords returns the words reached in the most recent test
+func (m Model) ReachedWords() []string {
+	return m.game.ReachedWords()
+}
+
+// Keystrokes returns every key press of the most recent test, in order
+func (m Model) Keystrokes() []game.Keystroke {
+	return m.game.KeyLog
+}
+
 // View renders the current state of the Model as a string for display
 func (m Model) View() string {
 	if m.showResults {
+		// Quiet mode prints a single summary line after exiting instead
+		if m.opts.Quiet {
+			return ""
+		}
 		return m.renderResults()
 	}
 
 	var sections []string
 
-	timer := m.renderTimer()
+	// Minimal mode keeps the timer's line so the text sits where it normally does
+	timer := ""
+	if !m.opts.Minimal {
+		timer = m.renderTimer()
+	}
 	sections = append(sections, timer)
 
 	textDisplay := m.renderText()
 	sections = append(sections, textDisplay)
 
+	if m.reviewing {
+		sections = append(sections, timeStyle.Foreground(alertStyle.GetForeground()).Render(m.renderReview()))
+	} else if m.notice != "" {
+		sections = append(sections, timeStyle.Foreground(warnStyle.GetForeground()).Render(m.notice))
+	} else if m.opts.Tutorial {
+		sections = append(sections, timeStyle.Foreground(hintStyle.GetForeground()).Render(m.tutorialHint()))
+	} else if m.opts.UntilWPM > 0 {
+		sections = append(sections, timeStyle.Foreground(mutedStyle.GetForeground()).Render(m.renderTarget()))
+	} else if m.game.IsOpenEnded() {
+		sections = append(sections, timeStyle.Foreground(mutedStyle.GetForeground()).Render(m.keyLabel(config.ActionFinish)+" to finish"))
+	}
+
 	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
 
 	return lipgloss.Place(
@@ -253,33 +869,80 @@ func (m Model) View() string {
 	)
 }
 
-// renderTimer formats the remaining time for display
+// targetSustained reports whether the WPM over the last game.SustainWindow
+// has reached the --until-wpm target
+func (m Model) targetSustained() bool {
+	if m.opts.UntilWPM <= 0 || m.game.IsFinished {
+		return false
+	}
+	wpm, ok := m.game.RecentWPM(game.SustainWindow)
+	return ok && wpm >= m.opts.UntilWPM
+}
+
+// renderTarget shows the --until-wpm target and, unless in minimal mode, the
+// WPM over the last game.SustainWindow
+func (m Model) renderTarget() string {
+	window := int(game.SustainWindow.Seconds())
+	target := fmt.Sprintf("hold %.0f WPM for %ds", m.opts.UntilWPM, window)
+	if !m.opts.Minimal {
+		if wpm, ok := m.game.RecentWPM(game.SustainWindow); ok {
+			target = fmt.Sprintf("last %ds: %.0f / %.0f WPM", window, wpm, m.opts.UntilWPM)
+		}
+	}
+	return target + " • " + m.keyLabel(config.ActionFinish) + " to stop"
+}
+
+// keyLabel names the key bound to action for on-screen instructions
+func (m Model) keyLabel(action string) string {
+	return config.KeyLabel(m.config.Key(action))
+}
+
+// renderTimer formats the remaining time for display, or the elapsed time for open-ended tests
 func (m Model) renderTimer() string {
+	if m.game.IsOpenEnded() {
+		return timeStyle.Render(fmt.Sprintf("%d", m.game.GetElapsedTime())) + m.renderLiveWPM()
+	}
 	remaining := m.game.GetRemainingTime()
-	return timeStyle.Render(fmt.Sprintf("%d", remaining))
+	timer := timeStyle.Render(fmt.Sprintf("%d", remaining)) + m.renderLiveWPM()
+	if m.opts.WordsLeft {
+		if words, ok := m.game.WordsLeftEstimate(); ok {
+			timer += mutedStyle.Render(fmt.Sprintf("  ~%d words to go", words))
+		}
+	}
+	return timer
+}
+
+// renderLiveWPM formats the smoothed live WPM shown after the timer, or
+// nothing until it has a value
+func (m Model) renderLiveWPM() string {
+	if !m.opts.LiveWPM {
+		return ""
+	}
+	wpm, ok := m.liveWPM.Value()
+	if !ok {
+		return ""
+	}
+	return mutedStyle.Render(fmt.Sprintf("  %.0f wpm", wpm))
 }
 
 // renderText formats the text display with appropriate styles for typed, current, untyped characters
 func (m Model) renderText() string {
-	displayText := m.game.GetDisplayText()
-
-	var rendered strings.Builder
+	lines, width := m.formatIntoLines()
 
-	for i, char := range displayText {
-		// Use helper to style character
-		styledChar := m.styleChar(char, i)
-		rendered.WriteString(styledChar)
+	// Lines are packed to the game's width in terminal cells, but a word wider
+	// than a whole line overflows it; widen the box rather than wrap it
+	box := textBoxStyle
+	if padded := width + textBoxStyle.GetHorizontalPadding(); padded > textBoxStyle.GetWidth() {
+		box = box.Copy().Width(padded)
 	}
-
-	// Format into lines
-	content := rendered.String()
-	lines := m.formatIntoLines(content)
-
-	return textBoxStyle.Render(strings.Join(lines, "\n"))
+	return box.Render(strings.Join(lines, "\n"))
 }
 
-// formatIntoLines formats the content into lines based on the game's display settings
-func (m Model) formatIntoLines(plainContent string) []string {
+// formatIntoLines styles each visible display line, returning the lines and
+// the widest one's width in terminal cells, including a caret past its end.
+// Characters are indexed by rune in GetDisplayText(), where lines are joined
+// by a single space; wide (e.g. CJK) characters take two cells.
+func (m Model) formatIntoLines() ([]string, int) {
 	lines := m.game.DisplayLines
 
 	maxLines := m.game.LinesPerView
@@ -289,49 +952,94 @@ func (m Model) formatIntoLines(plainContent string) []string {
 
 	var styledLines []string
 	charIndex := 0
+	widest := 0
+	frame := m.textFrame()
 
 	for i, line := range lines {
-		if i >= maxLines {
-			break
-		}
-
 		var styledLine strings.Builder
-
 		lineRunes := []rune(line)
+		lineWidth := runewidth.StringWidth(line)
 
-		for col := 0; col < len(lineRunes); col++ {
-			if charIndex < len(plainContent) {
-				styledChar := m.styleChar(lineRunes[col], charIndex)
-				styledLine.WriteString(styledChar)
-				charIndex++
-			} else {
-				styledLine.WriteString(mutedStyle.Render(string(lineRunes[col])))
-			}
+		for _, char := range lineRunes {
+			styledLine.WriteString(m.styleChar(char, charIndex, frame))
+			charIndex++
 		}
 
 		// Check if caret is on this line and positioned just beyond last char
-		caretPos := m.game.CurrentPos
-		if i == 0 && caretPos == len(lineRunes) {
+		if i == m.game.CurrentLine && m.game.CurrentPos == len(lineRunes) {
 			// Append caret style with a space or block to show cursor
-			styledLine.WriteString(cursorStyle.Render(" "))
+			if m.caretHidden {
+				styledLine.WriteString(" ")
+			} else {
+				styledLine.WriteString(cursorStyle.Render(" "))
+			}
+			lineWidth++
 		}
 
 		styledLines = append(styledLines, styledLine.String())
+		widest = max(widest, lineWidth)
 
-		// Advance charIndex as before for spacing between lines
-		if charIndex < len(plainContent) && i < len(lines)-1 {
-			charIndex++
-		}
+		// Skip the space joining this line to the next
+		charIndex++
 	}
 
-	return styledLines
+	return styledLines, widest
+}
+
+// frame holds the positions in the display text that styling a character
+// depends on, worked out once per render rather than once per character
+type frame struct {
+	caret       int // Rune offset of the caret
+	wordStart   int // [wordStart, wordEnd) is the word under the caret, for focus mode
+	wordEnd     int
+	recentStart int  // Where the most recently typed word starts
+	ghost       int  // Rune offset of the ghost caret
+	hasGhost    bool // Whether the ghost is on screen
+}
+
+// textFrame works out the positions used to style this render's characters
+func (m Model) textFrame() frame {
+	f := frame{caret: m.game.CaretOffset()}
+	if m.opts.Focus {
+		f.wordStart, f.wordEnd = m.currentWordBounds()
+	}
+	if m.config.RecentWord {
+		f.recentStart = m.recentWordStart()
+	}
+	if m.ghostWPM > 0 {
+		f.ghost, f.hasGhost = m.game.GhostOffset(m.ghostWPM)
+	}
+	return f
 }
 
 // styleChar determines the style of a character based on its position and error status
-func (m Model) styleChar(char rune, index int) string {
-	userPos := m.game.CurrentPos
+func (m Model) styleChar(char rune, index int, f frame) string {
+	userPos := f.caret
 	errorIndex := m.game.GlobalPos - (userPos - index)
 
+	// In focus mode everything outside the current word is heavily dimmed
+	if m.opts.Focus && index != userPos && (index < f.wordStart || index >= f.wordEnd) {
+		return focusDimStyle.Render(string(char))
+	}
+
+	// The ghost is drawn over anything but the caret and mistakes
+	if f.hasGhost && f.ghost == index && index != userPos && !m.game.Errors[errorIndex] {
+		return ghostStyle.Render(string(char))
+	}
+
+	// Review mode singles out the mistake it paused on
+	if m.reviewing && index == userPos-1 {
+		return errorStyle.Copy().Reverse(true).Render(string(char))
+	}
+
+	// Spaces may be drawn as a faint glyph; matching still uses the real space
+	if char == ' ' && m.opts.SpaceGlyph != "" && index != userPos {
+		if index < userPos && m.game.Errors[errorIndex] {
+			return errorStyle.Render(m.opts.SpaceGlyph)
+		}
+		return mutedStyle.Render(m.opts.SpaceGlyph)
+	}
+
 	switch {
 	case index < userPos:
 		// Already typed
@@ -340,9 +1048,18 @@ func (m Model) styleChar(char rune, index int) string {
 				return errorStyle.Render(string(char))
 			}
 		}
+		if m.opts.ShowCorrections && m.game.Corrected[errorIndex] {
+			return correctedStyle.Render(string(char))
+		}
+		if m.config.RecentWord && index < f.recentStart {
+			return settledStyle.Render(string(char))
+		}
 		return boldStyle.Render(string(char))
 	case index == userPos:
-		// Current character
+		// Current character (drawn as untyped while a blinking caret is off)
+		if m.caretHidden {
+			return mutedStyle.Render(string(char))
+		}
 		return cursorStyle.Render(string(char))
 	default:
 		// Not yet typed
@@ -350,26 +1067,128 @@ func (m Model) styleChar(char rune, index int) string {
 	}
 }
 
+// currentWordBounds returns the [start, end) rune range of the word under the caret
+// in the display text. When the caret sits on a space, the following word is used.
+func (m Model) currentWordBounds() (int, int) {
+	text := []rune(m.game.GetDisplayText())
+	pos := m.game.CaretOffset()
+	if pos < len(text) && text[pos] == ' ' {
+		pos++
+	}
+
+	start := pos
+	for start > 0 && text[start-1] != ' ' {
+		start--
+	}
+	end := pos
+	for end < len(text) && text[end] != ' ' {
+		end++
+	}
+	return start, end
+}
+
+// recentWordStart returns where the most recently typed word starts in the
+// display text: the word under the caret, or the one just finished if the
+// caret is between words
+func (m Model) recentWordStart() int {
+	text := []rune(m.game.GetDisplayText())
+	start := min(m.game.CaretOffset(), len(text))
+	for start > 0 && text[start-1] == ' ' {
+		start--
+	}
+	for start > 0 && text[start-1] != ' ' {
+		start--
+	}
+	return start
+}
+
 // renderResults formats the final results of the typing test for display
 func (m Model) renderResults() string {
+	var view string
+	switch m.resultsView {
+	case resultsSlowestWords:
+		view = m.renderSlowestWords()
+	case resultsFingers:
+		view = m.renderFingerAccuracy()
+	case resultsLatency:
+		view = m.renderLatency()
+	case resultsReplay:
+		view = m.renderReplay()
+	default:
+		view = m.renderSummary()
+	}
+
+	restart := m.keyLabel(config.ActionRestart) + " for new words"
+	if m.opts.RestartMode == config.RestartSame {
+		restart = m.keyLabel(config.ActionRestart) + " to retype the same words"
+	}
+	instructions := mutedStyle.Render(fmt.Sprintf("1-%d or ←/→ to switch view • %s • %s to quit", resultsViewCount, restart, m.keyLabel(config.ActionQuit)))
+	lines := []string{m.renderResultsTabs(), spacer, view, spacer, instructions}
+	if m.opts.Tutorial {
+		lines = append(lines, spacer, m.tutorialResultsGuide())
+	}
+	if m.loopRemaining > 0 {
+		countdown := warnStyle.
+			Render(fmt.Sprintf("Next test in %ds — press any key to stay", m.loopRemaining))
+		lines = append(lines, spacer, countdown)
+	}
+	content := lipgloss.JoinVertical(lipgloss.Center, lines...)
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		resultsContainerStyle.Render(content),
+	)
+}
+
+// renderResultsTabs draws the row of results views with the current one highlighted
+func (m Model) renderResultsTabs() string {
+	var tabs []string
+	for i, name := range resultsViewNames {
+		label := fmt.Sprintf("%d %s", i+1, name)
+		if resultsView(i) == m.resultsView {
+			tabs = append(tabs, boldStyle.Copy().Underline(true).Render(label))
+		} else {
+			tabs = append(tabs, mutedStyle.Render(label))
+		}
+	}
+	return strings.Join(tabs, mutedStyle.Render("  │  "))
+}
+
+// renderSummary formats the headline stats of the finished test
+func (m Model) renderSummary() string {
 	stats := m.finalStats
 
+	accLabel := "acc"
+	if stats.AccuracyModel != game.AccuracyStandard {
+		accLabel = "acc (" + stats.AccuracyModel + ")"
+	}
 	accSection := lipgloss.JoinVertical(
 		lipgloss.Right,
-		mutedStyle.Render("acc"),
-		boldStyle.Render(fmt.Sprintf("%.0f%%", stats.Accuracy)),
+		mutedStyle.Render(accLabel),
+		accuracyStyle(stats.Accuracy).Render(m.formatStat(stats.Accuracy)+"%"),
 	)
 
+	wpm := m.formatStat(stats.WPM)
+	if stats.Invalid != "" {
+		wpm = "—"
+	}
 	wpmSection := lipgloss.JoinVertical(
 		lipgloss.Right,
 		mutedStyle.Render("wpm"),
-		boldStyle.Render(fmt.Sprintf("%.0f", stats.WPM)),
+		boldStyle.Render(wpm),
+	)
+
+	errSection := lipgloss.JoinVertical(
+		lipgloss.Right,
+		mutedStyle.Render("err/min"),
+		boldStyle.Render(fmt.Sprintf("%.1f", stats.ErrorsPerMinute)),
 	)
 
 	timeSection := lipgloss.JoinVertical(
 		lipgloss.Right,
 		mutedStyle.Render("time"),
-		boldStyle.Render(fmt.Sprintf("%.0fs", stats.TimeElapsed.Seconds())),
+		boldStyle.Render(m.formatStat(stats.TimeElapsed.Seconds())+"s"),
 	)
 
 	languageSection := lipgloss.JoinVertical(
@@ -378,19 +1197,47 @@ func (m Model) renderResults() string {
 		boldStyle.Render(m.language),
 	)
 
+	// Raw stats count deleted characters and backspaces as keystrokes
+	var rawSection string
+	if m.opts.CountCorrections {
+		rawSection = lipgloss.JoinVertical(
+			lipgloss.Right,
+			mutedStyle.Render("raw wpm/acc"),
+			boldStyle.Render(fmt.Sprintf("%s / %s%%", m.formatStat(stats.RawWPM), m.formatStat(stats.RawAccuracy))),
+		)
+	}
+
 	// Add rank section for 60-second tests
 	var rankSection string
-	if m.duration == 60 {
-		if m.submitting {
+	if m.duration == RankedDuration {
+		if stats.Invalid != "" {
 			rankSection = lipgloss.JoinVertical(
 				lipgloss.Right,
 				mutedStyle.Render("rank"),
-				boldStyle.Render("..."),
+				mutedStyle.Render("n/a"),
+			)
+		} else if m.opts.NoSubmit {
+			rankSection = lipgloss.JoinVertical(
+				lipgloss.Right,
+				mutedStyle.Render("rank"),
+				mutedStyle.Render("off"),
+			)
+		} else if m.skippedNotPB {
+			rankSection = lipgloss.JoinVertical(
+				lipgloss.Right,
+				mutedStyle.Render("rank"),
+				mutedStyle.Render("skip"),
+			)
+		} else if m.submitting {
+			rankSection = lipgloss.JoinVertical(
+				lipgloss.Right,
+				mutedStyle.Render("rank"),
+				boldStyle.Render(fmt.Sprintf("%ds…", int(time.Since(m.submitStarted).Seconds()))),
 			)
 		} else if m.userRank > 0 {
 			rankText := fmt.Sprintf("#%d", m.userRank)
 			if m.userRank <= 10 {
-				rankText = lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true).Render(rankText)
+				rankText = warnStyle.Bold(true).Render(rankText)
 			} else {
 				rankText = boldStyle.Render(rankText)
 			}
@@ -403,7 +1250,7 @@ func (m Model) renderResults() string {
 			rankSection = lipgloss.JoinVertical(
 				lipgloss.Right,
 				mutedStyle.Render("rank"),
-				lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render("error"),
+				alertStyle.Render("error"),
 			)
 		} else if !m.isAuthenticated {
 			rankSection = lipgloss.JoinVertical(
@@ -411,91 +1258,577 @@ func (m Model) renderResults() string {
 				mutedStyle.Render("rank"),
 				mutedStyle.Render("n/a"),
 			)
+		} else if m.finalStats.Accuracy < RankedMinAccuracy {
+			rankSection = lipgloss.JoinVertical(
+				lipgloss.Right,
+				mutedStyle.Render("rank"),
+				mutedStyle.Render("85%+"),
+			)
 		} else if m.userRank == 0 {
             rankSection = lipgloss.JoinVertical(
                 lipgloss.Right,
                 mutedStyle.Render("rank"),
                 mutedStyle.Render("n/a"),
             )
-        } else if m.finalStats.Accuracy < 85.0 {
-			rankSection = lipgloss.JoinVertical(
-				lipgloss.Right,
-				mutedStyle.Render("rank"),
-				mutedStyle.Render("85%+"),
-			)
-		}
+        }
 	}
 
 	// Arrange stats horizontally
-	var statsRow string
+	sections := []string{accSection, wpmSection, errSection, timeSection, languageSection}
+	if rawSection != "" {
+		sections = append(sections, rawSection)
+	}
 	if rankSection != "" {
-		statsRow = lipgloss.JoinHorizontal(
-			lipgloss.Top,
-			accSection,
-			strings.Repeat(" ", statGap),
-			wpmSection,
-			strings.Repeat(" ", statGap),
-			timeSection,
-			strings.Repeat(" ", statGap),
-			languageSection,
-			strings.Repeat(" ", statGap),
-			rankSection,
-		)
+		sections = append(sections, rankSection)
+	}
+	var row []string
+	for i, section := range sections {
+		if i > 0 {
+			row = append(row, strings.Repeat(" ", statGap))
+		}
+		row = append(row, section)
+	}
+	statsRow := lipgloss.JoinHorizontal(lipgloss.Top, row...)
+
+	// Results layout
+	resultsLines := []string{statsRow}
+	if stats.Invalid != "" {
+		resultsLines = append(resultsLines, spacer, warnStyle.
+			Render("⚠ Result not saved or submitted: "+stats.Invalid))
+	}
+	if m.targetReached {
+		resultsLines = append(resultsLines, spacer, goodStyle.Bold(true).
+			Render(fmt.Sprintf("🏁 Held %.0f WPM for %ds — target reached!", m.opts.UntilWPM, int(game.SustainWindow.Seconds()))))
+	}
+	if m.opts.Adaptive.Difficulty != "" {
+		resultsLines = append(resultsLines, spacer, mutedStyle.Render(m.opts.Adaptive.String()))
+	}
+	if m.opts.LocalName != "" && !m.opts.Review {
+		local := fmt.Sprintf("local board: saved as %s", m.opts.LocalName)
+		if m.duration != localboard.TargetDuration || m.finalStats.Accuracy < localboard.MinAccuracy {
+			local = fmt.Sprintf("local board: only %ds tests with %.0f%%+ accuracy are ranked", localboard.TargetDuration, localboard.MinAccuracy)
+		}
+		resultsLines = append(resultsLines, spacer, mutedStyle.Render(local))
+	}
+	if m.skippedNotPB {
+		resultsLines = append(resultsLines, spacer, mutedStyle.Render(fmt.Sprintf("not a PB (best %.0f WPM) — skipped", m.knownBest)))
+	}
+	if m.rankGap > 0 && m.userRank > 1 {
+		resultsLines = append(resultsLines, spacer, mutedStyle.Render(fmt.Sprintf("%.0f WPM to reach rank #%d", m.rankGap, m.userRank-1)))
+	}
+	if m.submitting {
+		resultsLines = append(resultsLines, spacer, mutedStyle.Render(m.renderSubmitting()))
+	}
+	if m.submitQueued {
+		resultsLines = append(resultsLines, spacer, mutedStyle.Render("offline: score saved, it will be submitted the next time you run zt"))
+	}
+	if m.submittedWPM > 0 {
+		resultsLines = append(resultsLines, spacer, mutedStyle.Render(fmt.Sprintf("submitted: %s gross WPM", m.formatStat(m.submittedWPM))))
+		if m.opts.CompareAverage && m.opts.ChallengeDate == "" && m.globalStats != nil && m.globalStats.QualifiedScores > 0 {
+			resultsLines = append(resultsLines, spacer, mutedStyle.Render(m.renderAverageComparison()))
+		}
+	}
+	if m.personalBest {
+		resultsLines = append(resultsLines, spacer, m.renderPersonalBest())
+	}
+	if m.config.HasGoals() {
+		resultsLines = append(resultsLines, spacer, m.renderGoals())
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Center, resultsLines...)
+}
+
+// renderSubmitting describes a submission in progress, with a way out once
+// the server has been slow for SubmitSlowAfter
+func (m Model) renderSubmitting() string {
+	elapsed := time.Since(m.submitStarted)
+	if elapsed < SubmitSlowAfter {
+		return fmt.Sprintf("submitting… %ds", int(elapsed.Seconds()))
+	}
+	return fmt.Sprintf("submitting… %ds — the server is slow to respond; %s quits, but the score may not be recorded",
+		int(elapsed.Seconds()), m.keyLabel(config.ActionQuit))
+}
+
+// formatStat formats a WPM, accuracy or time figure with the configured
+// number of decimals
+func (m Model) formatStat(value float64) string {
+	return strconv.FormatFloat(value, 'f', m.config.Decimals, 64)
+}
+
+// accuracyStyle picks the results color band: red below the 85% leaderboard
+// minimum, yellow from 85% to 95%, green above 95%
+func accuracyStyle(accuracy float64) lipgloss.Style {
+	switch {
+	case accuracy < RankedMinAccuracy:
+		return accuracyLowStyle
+	case accuracy <= 95.0:
+		return accuracyMidStyle
+	default:
+		return accuracyHighStyle
+	}
+}
+
+// renderSlowestWords formats the per-word pacing breakdown, slowest words first
+func (m Model) renderSlowestWords() string {
+	slowest := m.game.SlowestWords(10)
+
+	var rows []string
+	rows = append(rows, boldStyle.Render("Slowest words"), spacer)
+
+	if len(slowest) == 0 {
+		rows = append(rows, mutedStyle.Render("No completed words to analyse"))
 	} else {
-		statsRow = lipgloss.JoinHorizontal(
+		wordStyle := lipgloss.NewStyle().Width(16).Align(lipgloss.Left)
+		numStyle := lipgloss.NewStyle().Width(8).Align(lipgloss.Right)
+
+		rows = append(rows, lipgloss.JoinHorizontal(
 			lipgloss.Top,
-			accSection,
-			strings.Repeat(" ", statGap),
-			wpmSection,
-			strings.Repeat(" ", statGap),
-			timeSection,
-			strings.Repeat(" ", statGap),
-			languageSection,
-		)
+			mutedStyle.Copy().Inherit(wordStyle).Render("word"),
+			mutedStyle.Copy().Inherit(numStyle).Render("time"),
+			mutedStyle.Copy().Inherit(numStyle).Render("wpm"),
+		))
+		for i, timing := range slowest {
+			style := boldStyle
+			if i < 3 {
+				style = errorStyle.Copy().Underline(false)
+			}
+			rows = append(rows, lipgloss.JoinHorizontal(
+				lipgloss.Top,
+				style.Copy().Inherit(wordStyle).Render(timing.Word),
+				style.Copy().Inherit(numStyle).Render(fmt.Sprintf("%.2fs", timing.Duration.Seconds())),
+				style.Copy().Inherit(numStyle).Render(fmt.Sprintf("%.0f", timing.WPM())),
+			))
+		}
 	}
 
-	instructions := mutedStyle.Align(lipgloss.Center).Render("Press Enter to restart • Esc to quit")
+	return lipgloss.JoinVertical(lipgloss.Center, rows...)
+}
 
-	// Results layout
-	resultsContent := lipgloss.JoinVertical(
-		lipgloss.Center,
-		spacer,
-		statsRow,
-		spacer,
-		instructions,
-	)
+// renderFingerAccuracy shows accuracy per touch-typing finger as a bar table
+func (m Model) renderFingerAccuracy() string {
+	var rows []string
+	rows = append(rows, boldStyle.Render("Accuracy by finger"), spacer)
+
+	nameStyle := lipgloss.NewStyle().Width(10).Align(lipgloss.Left)
+	numStyle := lipgloss.NewStyle().Width(8).Align(lipgloss.Right)
+	const barWidth = 20
+
+	for _, stat := range m.game.FingerAccuracy() {
+		if stat.Attempts == 0 {
+			rows = append(rows, lipgloss.JoinHorizontal(
+				lipgloss.Top,
+				mutedStyle.Copy().Inherit(nameStyle).Render(stat.Finger.String()),
+				mutedStyle.Render(strings.Repeat("·", barWidth)),
+				mutedStyle.Copy().Inherit(numStyle).Render("—"),
+			))
+			continue
+		}
 
-	return lipgloss.Place(
-		m.width, m.height,
-		lipgloss.Center, lipgloss.Center,
-		resultsContainerStyle.Render(resultsContent),
-	)
+		acc := stat.Accuracy()
+		filled := int(math.Round(acc / 100 * barWidth))
+		style := accuracyStyle(acc)
+		rows = append(rows, lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			nameStyle.Render(stat.Finger.String()),
+			style.Render(strings.Repeat("█", filled))+mutedStyle.Render(strings.Repeat("·", barWidth-filled)),
+			style.Copy().Inherit(numStyle).Render(fmt.Sprintf("%.0f%%", acc)),
+			mutedStyle.Copy().Inherit(numStyle).Render(fmt.Sprintf("%d missed", stat.Misses)),
+		))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// renderLatency shows the mean and median time between key presses
+func (m Model) renderLatency() string {
+	rows := []string{boldStyle.Render("Time between keystrokes"), spacer}
+
+	mean, median, ok := m.game.KeystrokeIntervals()
+	if !ok {
+		rows = append(rows, mutedStyle.Render("Not enough keystrokes to measure"))
+		return lipgloss.JoinVertical(lipgloss.Left, rows...)
+	}
+
+	labelStyle := lipgloss.NewStyle().Width(12).Align(lipgloss.Left)
+	numStyle := lipgloss.NewStyle().Width(10).Align(lipgloss.Right)
+	for _, row := range []struct {
+		label string
+		value string
+	}{
+		{"mean", fmt.Sprintf("%d ms", mean.Milliseconds())},
+		{"median", fmt.Sprintf("%d ms", median.Milliseconds())},
+		{"keystrokes", fmt.Sprintf("%d", len(m.game.KeyLog))},
+	} {
+		rows = append(rows, lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			mutedStyle.Copy().Inherit(labelStyle).Render(row.label),
+			boldStyle.Copy().Inherit(numStyle).Render(row.value),
+		))
+	}
+
+	// Top gear: the fastest stretch of the test, next to the average
+	if peak, ok := m.game.PeakWPM(game.PeakWindow); ok {
+		rows = append(rows, spacer, lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			mutedStyle.Copy().Inherit(labelStyle).Render(fmt.Sprintf("peak %ds", int(game.PeakWindow.Seconds()))),
+			boldStyle.Copy().Inherit(numStyle).Render(fmt.Sprintf("%.0f wpm", peak)),
+		))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// Size of the replay view
+const (
+	replayWidth    = 50 // Cells per line, like the test itself
+	replayMaxLines = 10
+)
+
+// renderReplay shows what was typed against the text, aligned so a skipped or
+// doubled character is one error rather than shifting everything after it
+func (m Model) renderReplay() string {
+	rows := []string{boldStyle.Render("What you typed"), spacer}
+
+	ops := m.game.TypedAlignment()
+	if len(ops) == 0 {
+		rows = append(rows, mutedStyle.Render("Nothing typed"))
+		return lipgloss.JoinVertical(lipgloss.Left, rows...)
+	}
+
+	var lines []string
+	var line, word strings.Builder
+	lineWidth, wordWidth := 0, 0
+	flushWord := func() {
+		if lineWidth > 0 && lineWidth+wordWidth > replayWidth {
+			lines = append(lines, line.String())
+			line.Reset()
+			lineWidth = 0
+		}
+		line.WriteString(word.String())
+		lineWidth += wordWidth
+		word.Reset()
+		wordWidth = 0
+	}
+
+	substituted, missing, extra := 0, 0, 0
+	for _, op := range ops {
+		switch op.Kind {
+		case game.DiffMatch:
+			word.WriteRune(op.Typed)
+		case game.DiffSubstitute:
+			substituted++
+			word.WriteString(errorStyle.Render(visibleRune(op.Expected)))
+		case game.DiffMissing:
+			missing++
+			word.WriteString(missingStyle.Render(visibleRune(op.Expected)))
+		case game.DiffExtra:
+			extra++
+			word.WriteString(extraStyle.Render(visibleRune(op.Typed)))
+		}
+		wordWidth++
+		// Lines break after a correctly typed space, like the test's own lines
+		if op.Kind == game.DiffMatch && op.Typed == ' ' {
+			flushWord()
+		}
+	}
+	flushWord()
+	if lineWidth > 0 {
+		lines = append(lines, line.String())
+	}
+
+	if len(lines) > replayMaxLines {
+		more := len(lines) - replayMaxLines
+		lines = append(lines[:replayMaxLines], mutedStyle.Render(fmt.Sprintf("… %d more line(s)", more)))
+	}
+	rows = append(rows, lines...)
+
+	rows = append(rows, spacer,
+		errorStyle.Render("wrong")+mutedStyle.Render(fmt.Sprintf(" %d  ", substituted))+
+			missingStyle.Render("skipped")+mutedStyle.Render(fmt.Sprintf(" %d  ", missing))+
+			extraStyle.Render("extra")+mutedStyle.Render(fmt.Sprintf(" %d", extra)))
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// visibleRune renders a space as a middle dot so errors on spaces can be seen
+func visibleRune(r rune) string {
+	if r == ' ' {
+		return "·"
+	}
+	return string(r)
+}
+
+// renderGoals shows progress of this run toward the goals set with zt goal
+func (m Model) renderGoals() string {
+	stats := m.finalStats
+	progress := func(met bool, text string) string {
+		if met {
+			return accuracyHighStyle.Render(text)
+		}
+		return mutedStyle.Render(text)
+	}
+
+	var parts []string
+	if m.config.GoalWPM > 0 {
+		parts = append(parts, progress(stats.WPM >= m.config.GoalWPM,
+			fmt.Sprintf("%.0f/%.0f WPM", stats.WPM, m.config.GoalWPM)))
+	}
+	if m.config.GoalAccuracy > 0 {
+		parts = append(parts, progress(stats.Accuracy >= m.config.GoalAccuracy,
+			fmt.Sprintf("%.0f/%.0f%% acc", stats.Accuracy, m.config.GoalAccuracy)))
+	}
+
+	line := mutedStyle.Render("goal: ") + strings.Join(parts, mutedStyle.Render(" • "))
+	if m.config.GoalsMet(stats.WPM, stats.Accuracy) {
+		celebration := goodStyle.Bold(true).Render("🎯 Goal reached!")
+		return lipgloss.JoinVertical(lipgloss.Center, celebration, line)
+	}
+	return line
+}
+
+// renderAverageComparison describes how this result compares with the average
+// qualifying score of all players, e.g. "+15 WPM above average"
+func (m Model) renderAverageComparison() string {
+	describe := func(diff float64, unit, name string) string {
+		switch {
+		case math.Round(diff) > 0:
+			return fmt.Sprintf("+%.0f%s above average", diff, unit)
+		case math.Round(diff) < 0:
+			return fmt.Sprintf("%.0f%s below average", -diff, unit)
+		default:
+			return "average " + name
+		}
+	}
+	wpm := describe(m.finalStats.WPM-m.globalStats.AverageWPM, " WPM", "WPM")
+	accuracy := describe(m.finalStats.Accuracy-m.globalStats.AverageAccuracy, "% accuracy", "accuracy")
+	return fmt.Sprintf("vs all players: %s · %s", wpm, accuracy)
+}
+
+// renderPersonalBest formats the celebration shown when a submitted score beats the previous best
+func (m Model) renderPersonalBest() string {
+	text := "🎉 New personal best!"
+	if m.pbGain > 0 {
+		text = fmt.Sprintf("🎉 New personal best! +%.0f WPM", m.pbGain)
+	}
+	return warnStyle.Bold(true).Render(text)
+}
+
+// generatorVersion is the word generator version recorded with the run's
+// seed, or 0 for runs without one
+func (m Model) generatorVersion() int {
+	if m.seed == 0 {
+		return 0
+	}
+	return game.GeneratorVersion
+}
+
+// recordHistory appends the finished test to the local history file
+func (m Model) recordHistory() {
+	store, err := history.NewStore()
+	if err != nil {
+		return // History is best-effort and never blocks the results screen
+	}
+	store.Append(history.Entry{
+		Timestamp:       time.Now(),
+		WPM:             m.finalStats.WPM,
+		Accuracy:        m.finalStats.Accuracy,
+		ErrorsPerMinute: m.finalStats.ErrorsPerMinute,
+		Duration:        m.duration,
+		Language:        m.language,
+		Seed:            m.seed,
+		GeneratorVersion: m.generatorVersion(),
+		Keys:            m.keyHistory(),
+		WordDist:        m.wordDist(),
+		AccuracyModel:   m.accuracyModel(),
+	})
+}
+
+// recordLocalScore adds the finished test to the local leaderboard under the
+// --name nickname, if one was given
+func (m Model) recordLocalScore() {
+	if m.opts.LocalName == "" {
+		return
+	}
+	store, err := localboard.NewStore()
+	if err != nil {
+		return // Like history, the local board never blocks the results screen
+	}
+	store.Record(localboard.Score{
+		Name:      m.opts.LocalName,
+		WPM:       m.finalStats.WPM,
+		Accuracy:  m.finalStats.Accuracy,
+		Duration:  m.duration,
+		Language:  m.language,
+		Timestamp: time.Now(),
+	})
+}
+
+// ghostPace returns the WPM the ghost races at: the best local result for a
+// test of the same duration and language, or 0 if the ghost is off or there is none
+func (m Model) ghostPace() float64 {
+	if !m.opts.Ghost {
+		return 0
+	}
+	store, err := history.NewStore()
+	if err != nil {
+		return 0
+	}
+	entries, err := store.Load()
+	if err != nil {
+		return 0
+	}
+
+	best := 0.0
+	for _, entry := range entries {
+		if entry.Duration == m.duration && entry.Language == m.language && entry.WPM > best {
+			best = entry.WPM
+		}
+	}
+	return best
+}
+
+// wordDist returns the distribution to record with the seed; empty for
+// uniform and for custom generators, whose seed is 0 anyway
+func (m Model) wordDist() string {
+	if m.opts.Generator != nil || m.opts.WordDist != game.DistFrequency {
+		return ""
+	}
+	return game.DistFrequency
+}
+
+// accuracyModel returns the accuracy model to record with the run; empty for
+// the standard model so older and newer entries compare directly
+func (m Model) accuracyModel() string {
+	if m.finalStats.AccuracyModel == game.AccuracyStandard {
+		return ""
+	}
+	return m.finalStats.AccuracyModel
+}
+
+// keyHistory converts the per-key stats of the finished test for the history file
+func (m Model) keyHistory() map[string]history.KeyStat {
+	if len(m.game.KeyStats) == 0 {
+		return nil
+	}
+	keys := make(map[string]history.KeyStat, len(m.game.KeyStats))
+	for key, stat := range m.game.KeyStats {
+		keys[string(key)] = history.KeyStat{Attempts: stat.Attempts, Misses: stat.Misses}
+	}
+	return keys
+}
+
+// rankGap returns how many WPM the user needs to pass the player ranked
+// immediately above them, or 0 when there is nobody above
+func rankGap(stats *api.UserStats) float64 {
+	if stats.Rank <= 1 || stats.NextRankWPM <= 0 {
+		return 0
+	}
+	// Ties on WPM are broken by accuracy, so at least 1 WPM is always needed
+	return math.Max(math.Ceil(stats.NextRankWPM-stats.BestWPM), 1)
+}
+
+// knownBestCmd fetches the user's current best WPM once so submit_only_pb can
+// decide locally without an extra request after every test
+func (m Model) knownBestCmd() tea.Cmd {
+	return func() tea.Msg {
+		if stats, err := m.client.GetUserRank(m.language); err == nil {
+			return knownBestMsg{bestWPM: stats.BestWPM}
+		}
+		return knownBestMsg{}
+	}
+}
+
+// globalStatsCmd fetches the averages of all players; failures just leave the
+// comparison off the results screen
+func (m Model) globalStatsCmd() tea.Cmd {
+	return func() tea.Msg {
+		stats, err := m.client.GetGlobalStats()
+		if err != nil {
+			return globalStatsMsg{}
+		}
+		return globalStatsMsg{stats: stats}
+	}
 }
 
 // getRankCmd fetches the user's rank from the server
 func (m Model) getRankCmd() tea.Cmd {
     return func() tea.Msg {
         if stats, err := m.client.GetUserRank(m.language); err == nil {
-            return userRankMsg{rank: stats.Rank}
+            return userRankMsg{rank: stats.Rank, rankGap: rankGap(stats)}
         }
         return userRankMsg{rank: 0}
     }
 }
 
+// ranFullDuration reports whether the finished test is a ranked-length test
+// that actually ran for the whole duration. A test that ends early, however
+// it ends, is never submitted as a full one.
+func (m Model) ranFullDuration() bool {
+	return m.duration == RankedDuration && m.finalStats.TimeElapsed >= RankedDuration*time.Second
+}
+
+// qualifies reports whether the finished test can be ranked: a full-length
+// ranked test with the minimum accuracy. Only such tests are submitted or
+// celebrated as a personal best.
+func (m Model) qualifies() bool {
+	return m.ranFullDuration() && m.finalStats.Accuracy >= RankedMinAccuracy
+}
+
+// queueScore saves a score that couldn't reach the server so the next run of
+// zt can submit it, reporting whether it was saved
+func queueScore(stats game.TypingStats, duration int, language string, details api.ScoreDetails) bool {
+	store, err := pending.NewStore()
+	if err != nil {
+		return false
+	}
+	err = store.Add(pending.Score{
+		WPM:       stats.WPM,
+		Accuracy:  stats.Accuracy,
+		Duration:  duration,
+		Language:  language,
+		Seed:      details.Seed,
+		GeneratorVersion: details.GeneratorVersion,
+		WordDist:  details.WordDist,
+		Intervals: details.Intervals,
+		Timestamp: details.PlayedAt,
+		IdempotencyKey: details.IdempotencyKey,
+	})
+	return err == nil
+}
+
 // submitScore submits the user's score to the leaderboard
 func (m Model) submitScore() tea.Cmd {
+    details := api.ScoreDetails{
+        Seed:             m.seed,
+        GeneratorVersion: m.generatorVersion(),
+        WordDist:         m.wordDist(),
+        Intervals:        m.game.IntervalsMillis(),
+        PlayedAt:         time.Now(),
+        IdempotencyKey:   api.NewIdempotencyKey(),
+    }
     return func() tea.Msg {
-        entry, err := m.client.SubmitScore(m.finalStats, m.duration, m.language)
+        if m.opts.ChallengeDate != "" {
+            // The daily board ranks only today's challenge; its rank comes back directly
+            entry, err := m.client.SubmitDailyScore(m.finalStats, m.opts.ChallengeDate, details)
+            if err != nil {
+                return submitErrorMsg{error: err.Error()}
+            }
+            return scoreSubmittedMsg{entry: entry}
+        }
+
+        entry, err := m.client.SubmitScore(m.finalStats, m.duration, m.language, details)
         if err != nil {
-            return submitErrorMsg{error: err.Error()}
+            return submitErrorMsg{error: err.Error(), queued: api.IsUnreachable(err) && queueScore(m.finalStats, m.duration, m.language, details)}
         }
         // Always refresh rank after submission (server may calculate asynchronously)
+        var gap float64
         if stats, err := m.client.GetUserRank(m.language); err == nil {
             if entry == nil {
                 entry = &api.LeaderboardEntry{}
             }
             entry.Rank = stats.Rank
+            gap = rankGap(stats)
         }
-        return scoreSubmittedMsg{entry: entry}
+        return scoreSubmittedMsg{entry: entry, rankGap: gap}
     }
 }
\ No newline at end of file
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nemaniabhiram/zentype.cli/internal/game"
+)
+
+// typingModel returns a model for an open-ended test of repeated words, with
+// no config, session or network
+func typingModel(t *testing.T, word string) *Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("ZENTYPE_API_URL", "http://127.0.0.1:1")
+	words := strings.Fields(strings.Repeat(word+" ", game.MinWordPool))
+	m, err := NewModelWithOptions(0, "test", Options{
+		NoSubmit:  true,
+		Generator: func(count int) []string { return words[:min(count, len(words))] },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestKeyInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		word   string
+		msg    tea.KeyMsg
+		want   string // UserInput afterwards
+		notice bool   // Whether the paste notice is shown
+	}{
+		{name: "ascii", word: "word", msg: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")}, want: "w"},
+		{name: "accented", word: "été", msg: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("é")}, want: "é"},
+		{name: "input method commit", word: "日本語", msg: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("日本")}, want: "日本"},
+		{name: "bracketed paste", word: "word", msg: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("wo"), Paste: true}, notice: true},
+		{name: "long burst", word: "word", msg: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("word word word")}, notice: true},
+		{name: "unprintable", word: "word", msg: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("\u200b")}},
+		{name: "alt modified", word: "word", msg: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w"), Alt: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := typingModel(t, tt.word)
+			updated, _ := m.Update(tt.msg)
+			got := updated.(Model)
+			if got.game.UserInput != tt.want {
+				t.Errorf("UserInput = %q, want %q", got.game.UserInput, tt.want)
+			}
+			if pasted := strings.Contains(got.notice, "Pasting"); pasted != tt.notice {
+				t.Errorf("notice = %q, want paste notice: %v", got.notice, tt.notice)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// adminTokenFromEnv reads ADMIN_TOKEN; admin endpoints are disabled when it is empty
+func adminTokenFromEnv() string {
+	return strings.TrimSpace(os.Getenv("ADMIN_TOKEN"))
+}
+
+// requireAdmin checks the request carries the admin token, writing an error
+// response and returning false if it doesn't
+func (s *APIServer) requireAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if s.adminToken == "" {
+		http.NotFound(w, r) // Don't advertise admin endpoints that can't be used
+		return false
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+		http.Error(w, "Admin token required", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+// recomputeLeaderboard repairs denormalized score data, drops cached stats and
+// returns the canonical best score and rank of every user (public or not) on
+// the standard board for ?language=
+func (s *APIServer) recomputeLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
+
+	language := r.URL.Query().Get("language")
+	if language == "" {
+		language = "english"
+	}
+
+	// Scores keep a copy of the username; bring renamed users' old scores in line
+	result, err := s.db.Exec(`
+		UPDATE scores s SET username = u.username
+		FROM users u
+		WHERE s.github_id = u.github_id AND s.username <> u.username`)
+	if err != nil {
+		log.Printf("Error repairing score usernames: %v", err)
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+	repaired, _ := result.RowsAffected()
+
+	s.distributionCache.clear()
+	s.languageCache.clear()
+	s.rankHistoryCache.clear()
+
+	entries, err := s.queryRanked(rankFilter{Mode: ModeStandard, Language: language}, `ORDER BY rank`)
+	if err != nil {
+		log.Printf("Error recomputing leaderboard: %v", err)
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("🛠 Leaderboard recomputed for %s: %d users ranked, %d score usernames repaired", language, len(entries), repaired)
+
+	response := struct {
+		Language          string             `json:"language"`
+		RepairedUsernames int64              `json:"repaired_usernames"`
+		Entries           []LeaderboardEntry `json:"entries"`
+	}{
+		Language:          language,
+		RepairedUsernames: repaired,
+		Entries:           entries,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+)
+
+// Anti-cheat modes, chosen with the ANTICHEAT_MODE environment variable
+const (
+	AntiCheatOff    = "off"    // Don't inspect timing at all
+	AntiCheatFlag   = "flag"   // Store suspicious scores with suspect = TRUE for review (default)
+	AntiCheatReject = "reject" // Refuse suspicious scores
+)
+
+// Timing heuristics for spotting pasted or scripted input. Intervals are
+// optional, so a score sent without any is not judged. One sent with too few to
+// cover its characters is suspect, since it can't be told apart from pasted
+// text; the remaining checks need MinTimingSamples intervals.
+const (
+	MaxTimingSamples       = 5000 // Longer interval lists are rejected as malformed
+	MinTimingSamples       = 30   // Fewer intervals are too few to judge
+	MinHumanMedianInterval = 25   // Milliseconds; a median below this is a sustained ~480 WPM
+	MinHumanVariation      = 0.15 // Coefficient of variation; real typing is far less regular
+	BurstInterval          = 5    // Milliseconds; gaps this short are not separate key presses
+	MaxBurstShare          = 0.3  // Share of intervals allowed to be bursts
+	MinKeystrokeCoverage   = 0.9  // Share of scored characters that must have a key press
+)
+
+// antiCheatModeFromEnv reads ANTICHEAT_MODE, falling back to flagging
+func antiCheatModeFromEnv() string {
+	switch mode := os.Getenv("ANTICHEAT_MODE"); mode {
+	case AntiCheatOff, AntiCheatReject:
+		return mode
+	default:
+		return AntiCheatFlag
+	}
+}
+
+// suspiciousTiming checks the key press intervals sent with a score and
+// returns a reason if they are implausible for a human typing entry.WPM.
+// Scores sent without intervals, e.g. by older clients, are not checked.
+func suspiciousTiming(entry LeaderboardEntry) (bool, string) {
+	intervals := entry.Intervals
+	if len(intervals) == 0 {
+		return false, ""
+	}
+
+	// Every scored character needs a key press; far fewer presses means text was pasted
+	scoredChars := entry.WPM * 5 * float64(entry.Duration) / 60
+	if float64(len(intervals)+1) < scoredChars*MinKeystrokeCoverage {
+		return true, fmt.Sprintf("%d key presses for %.0f characters", len(intervals)+1, scoredChars)
+	}
+
+	if len(intervals) < MinTimingSamples {
+		return false, ""
+	}
+
+	total := 0
+	bursts := 0
+	for _, interval := range intervals {
+		if interval < 0 {
+			return true, "negative interval"
+		}
+		total += interval
+		if interval <= BurstInterval {
+			bursts++
+		}
+	}
+
+	// The presses must fit in the test (with a second of slack for rounding)
+	if total > (entry.Duration+1)*1000 {
+		return true, fmt.Sprintf("key presses span %dms in a %ds test", total, entry.Duration)
+	}
+
+	if share := float64(bursts) / float64(len(intervals)); share > MaxBurstShare {
+		return true, fmt.Sprintf("%.0f%% of key presses within %dms of the previous one", share*100, BurstInterval)
+	}
+
+	sorted := make([]int, len(intervals))
+	copy(sorted, intervals)
+	sort.Ints(sorted)
+	if median := sorted[len(sorted)/2]; median < MinHumanMedianInterval {
+		return true, fmt.Sprintf("median interval %dms", median)
+	}
+
+	mean := float64(total) / float64(len(intervals))
+	variance := 0.0
+	for _, interval := range intervals {
+		diff := float64(interval) - mean
+		variance += diff * diff
+	}
+	variation := math.Sqrt(variance/float64(len(intervals))) / mean
+	if variation < MinHumanVariation {
+		return true, fmt.Sprintf("intervals implausibly uniform (variation %.2f)", variation)
+	}
+
+	return false, ""
+}
@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+// humanIntervals returns n irregular key press intervals averaging about 150ms
+func humanIntervals(n int) []int {
+	intervals := make([]int, n)
+	for i := range intervals {
+		intervals[i] = 90 + (i*37)%120
+	}
+	return intervals
+}
+
+func TestSuspiciousTiming(t *testing.T) {
+	tests := []struct {
+		name      string
+		wpm       float64
+		intervals []int
+		want      bool
+	}{
+		{name: "no timing sent", wpm: 80, intervals: nil, want: false}, // Intervals are optional
+		{name: "too few key presses", wpm: 80, intervals: humanIntervals(20), want: true},
+		{name: "uniform intervals", wpm: 60, intervals: repeatInterval(60, 299), want: true},
+		{name: "human typing", wpm: 60, intervals: humanIntervals(299), want: false},
+		{name: "nothing typed", wpm: 0, intervals: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := LeaderboardEntry{WPM: tt.wpm, Duration: TargetDuration, Intervals: tt.intervals}
+			if got, reason := suspiciousTiming(entry); got != tt.want {
+				t.Errorf("suspiciousTiming = %v (%q), want %v", got, reason, tt.want)
+			}
+		})
+	}
+}
+
+// repeatInterval returns n identical intervals of ms milliseconds
+func repeatInterval(ms, n int) []int {
+	intervals := make([]int, n)
+	for i := range intervals {
+		intervals[i] = ms
+	}
+	return intervals
+}
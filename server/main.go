@@ -8,8 +8,12 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
@@ -29,6 +33,31 @@ type LeaderboardEntry struct {
 	Language  string    `json:"language"`
 	CreatedAt time.Time `json:"created_at"`
 	Rank      int       `json:"rank,omitempty"`
+
+	// GitHub handle, so clients can refer to users by login
+	GitHubLogin string `json:"github_login,omitempty"`
+
+	// Mode is "standard" or "daily"; daily scores also carry their challenge date
+	Mode          string `json:"mode,omitempty"`
+	ChallengeDate string `json:"challenge_date,omitempty"`
+
+	// Seed of the word generator used for the run, 0 if unknown, with the
+	// generator version and word distribution needed to replay it
+	Seed             int64  `json:"seed,omitempty"`
+	GeneratorVersion int    `json:"generator_version,omitempty"`
+	WordDist         string `json:"word_dist,omitempty"` // Empty for the uniform distribution
+
+	// Milliseconds between key presses, sent with submissions for anti-cheat checks
+	Intervals []int `json:"intervals,omitempty"`
+
+	// Sent with submissions: when the test was played (scores can arrive late
+	// from a client's offline queue) and a key that stores a score only once
+	PlayedAt       *time.Time `json:"played_at,omitempty"`
+	IdempotencyKey string     `json:"idempotency_key,omitempty"`
+
+	// Set only in submitScore responses
+	PersonalBest bool    `json:"personal_best,omitempty"`
+	PreviousBest float64 `json:"previous_best,omitempty"`
 }
 
 // UserStats represents user statistics and ranking
@@ -40,19 +69,162 @@ type UserStats struct {
 	Rank            int     `json:"rank"`
 	TotalScores     int     `json:"total_scores"`
 	QualifiedScores int     `json:"qualified_scores"`
+	NextRankWPM     float64 `json:"next_rank_wpm,omitempty"` // Best WPM of the user ranked immediately above
 }
 
 // APIServer handles all HTTP requests
 type APIServer struct {
-	db          *sql.DB
-	oauthConfig *oauth2.Config
+	db                *sql.DB
+	oauthConfig       *oauth2.Config
+	distributionCache *responseCache
+	languageCache     *responseCache
+	rankHistoryCache  *responseCache
+	antiCheatMode     string // AntiCheatOff, AntiCheatFlag or AntiCheatReject
+	adminToken        string // Bearer token for /admin endpoints; empty disables them
+}
+
+// responseCache holds computed responses for a short time to spare the database
+type responseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	data    interface{}
+	expires time.Time
+}
+
+// newResponseCache creates a cache whose entries expire after ttl
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{ttl: ttl, entries: make(map[string]cachedResponse)}
+}
+
+// get returns the cached response for key if it has not expired
+func (c *responseCache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.data, true
+}
+
+// set stores a response for key
+func (c *responseCache) set(key string, data interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cachedResponse{data: data, expires: time.Now().Add(c.ttl)}
+}
+
+// clear drops every cached response
+func (c *responseCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]cachedResponse)
+}
+
+// ActiveEntry ranks a user by how often they play rather than how fast
+type ActiveEntry struct {
+	Username        string `json:"username"`
+	GitHubID        int    `json:"github_id"`
+	GitHubLogin     string `json:"github_login"`
+	QualifiedScores int    `json:"qualified_scores"`
+	LongestStreak   int    `json:"longest_streak"` // Most consecutive days with a qualifying score
+	Rank            int    `json:"rank"`
+}
+
+// ActivityEntry is one recent qualifying submission in the activity feed
+type ActivityEntry struct {
+	Username    string    `json:"username"`
+	GitHubLogin string    `json:"github_login"`
+	WPM         float64   `json:"wpm"`
+	Accuracy    float64   `json:"accuracy"`
+	Language    string    `json:"language"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// WPMBucket is one bar of the WPM distribution histogram
+type WPMBucket struct {
+	MinWPM int `json:"min_wpm"`
+	MaxWPM int `json:"max_wpm"`
+	Count  int `json:"count"`
+}
+
+// LanguageStats summarizes qualifying standard scores in one language
+type LanguageStats struct {
+	Language        string  `json:"language"`
+	Users           int     `json:"users"`
+	Scores          int     `json:"scores"`
+	AverageWPM      float64 `json:"average_wpm"`
+	AverageAccuracy float64 `json:"average_accuracy"`
+	BestWPM         float64 `json:"best_wpm"`
+}
+
+// WPMDistribution is the histogram of best qualifying WPM per user
+type WPMDistribution struct {
+	Language   string      `json:"language"`
+	BucketSize int         `json:"bucket_size"`
+	TotalUsers int         `json:"total_users"`
+	Buckets    []WPMBucket `json:"buckets"`
 }
 
 const (
 	MinAccuracy    = 85.0 // Minimum accuracy to get on leaderboard
 	TargetDuration = 60   // Only 60-second tests count
+
+	MaxUsernameLength = 50 // Characters allowed in a display name (users.username)
+
+	MaxGeneratorVersion = 1000 // Sanity bound for the generator version sent with a seed
+
+	MaxIdempotencyKeyLength = 64                  // Characters allowed in a submission's idempotency key
+	MaxPlayedAtAge          = 30 * 24 * time.Hour // Older played_at times are replaced by the time received
+	MaxPlayedAtSkew         = 5 * time.Minute     // Allowance for a client clock running ahead
+
+	DefaultLeaderboardLimit = 10  // Entries returned when no limit is requested
+	MaxLeaderboardLimit     = 100 // Upper bound for the ?limit= parameter
+
+	ModeStandard = "standard" // Regular random-word tests
+	ModeDaily    = "daily"    // The shared daily challenge text
+	DateLayout   = "2006-01-02"
+
+	DistributionBucketSize = 20              // WPM width of each histogram bucket
+	DistributionCacheTTL   = 5 * time.Minute // How long a computed distribution is served
+
+	ServerVersion = "1.0.0"
+	// APIVersion is bumped whenever requests or responses change in a way older
+	// clients can't handle; clients compare it with their own and warn on mismatch
+	APIVersion       = 2
+	APIVersionHeader = "X-Zentype-API-Version"
 )
 
+// apiVersionMiddleware advertises the API version on every response and logs
+// requests from clients that speak a different version
+func apiVersionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(APIVersionHeader, strconv.Itoa(APIVersion))
+		if client := r.Header.Get(APIVersionHeader); client != "" && client != strconv.Itoa(APIVersion) {
+			log.Printf("Client API version %s differs from server version %d (%s %s)", client, APIVersion, r.Method, r.URL.Path)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// parseLimit reads the ?limit= query parameter, clamped to [1, MaxLeaderboardLimit]
+func parseLimit(r *http.Request) int {
+	raw := r.URL.Query().Get("limit")
+	if raw == "" {
+		return DefaultLeaderboardLimit
+	}
+
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit < 1 {
+		return DefaultLeaderboardLimit
+	}
+	return min(limit, MaxLeaderboardLimit)
+}
+
 // min returns the smaller of two integers
 func min(a, b int) int {
 	if a < b {
@@ -103,19 +275,26 @@ func main() {
 	log.Printf("✅ GitHub OAuth configured (Client ID: %s...)", oauthConfig.ClientID[:8])
 
 	server := &APIServer{
-		db:          db,
-		oauthConfig: oauthConfig,
+		db:                db,
+		oauthConfig:       oauthConfig,
+		distributionCache: newResponseCache(DistributionCacheTTL),
+		languageCache:     newResponseCache(DistributionCacheTTL),
+		rankHistoryCache:  newResponseCache(RankHistoryCacheTTL),
+		antiCheatMode:     antiCheatModeFromEnv(),
+		adminToken:        adminTokenFromEnv(),
 	}
 
 	// Setup routes
 	r := mux.NewRouter()
 	api := r.PathPrefix("/api").Subrouter()
+	api.Use(apiVersionMiddleware)
 
 	// CORS middleware - allow all origins for global client access
 	corsHandler := handlers.CORS(
 		handlers.AllowedOrigins([]string{"*"}),
 		handlers.AllowedMethods([]string{"GET", "POST", "OPTIONS"}),
-		handlers.AllowedHeaders([]string{"Content-Type", "Authorization"}),
+		handlers.AllowedHeaders([]string{"Content-Type", "Authorization", APIVersionHeader}),
+		handlers.ExposedHeaders([]string{APIVersionHeader}),
 		handlers.AllowCredentials(),
 	)
 
@@ -130,11 +309,25 @@ func main() {
 
 	// Leaderboard endpoints
 	api.HandleFunc("/scores", server.submitScore).Methods("POST")
+	api.HandleFunc("/scores/{id:[0-9]+}", server.getScore).Methods("GET")
 	api.HandleFunc("/leaderboard", server.getLeaderboard).Methods("GET")
+	api.HandleFunc("/leaderboard/daily", server.getDailyLeaderboard).Methods("GET")
+	api.HandleFunc("/leaderboard/active", server.getActiveLeaderboard).Methods("GET")
 	api.HandleFunc("/user/rank", server.getUserRank).Methods("GET")
+	api.HandleFunc("/user/rank-history", server.getRankHistory).Methods("GET")
+	api.HandleFunc("/user/summary", server.getUserSummary).Methods("GET")
+	api.HandleFunc("/user/visibility", server.setVisibility).Methods("POST")
+	api.HandleFunc("/user/name", server.setDisplayName).Methods("POST")
+	api.HandleFunc("/users/{login}", server.getPublicProfile).Methods("GET")
 
 	// Statistics endpoints
 	api.HandleFunc("/stats", server.getGlobalStats).Methods("GET")
+	api.HandleFunc("/stats/distribution", server.getDistribution).Methods("GET")
+	api.HandleFunc("/stats/languages", server.getLanguageStats).Methods("GET")
+	api.HandleFunc("/activity", server.getActivity).Methods("GET")
+
+	// Admin endpoints, only enabled when ADMIN_TOKEN is set
+	api.HandleFunc("/admin/recompute", server.recomputeLeaderboard).Methods("POST")
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -175,63 +368,9 @@ func getRedirectURL() string {
 	return fmt.Sprintf("http://localhost:%s/api/auth/github/callback", port)
 }
 
+// initDB brings the database schema up to date by applying pending migrations
 func initDB(db *sql.DB) error {
-	schema := `
-	-- Users table with GitHub integration
-	CREATE TABLE IF NOT EXISTS users (
-		id SERIAL PRIMARY KEY,
-		username VARCHAR(50) NOT NULL,
-		github_id INTEGER UNIQUE NOT NULL,
-		github_login VARCHAR(50) NOT NULL,
-		avatar_url TEXT,
-		access_token TEXT,
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);
-
-	-- Scores table for 60-second tests only
-	CREATE TABLE IF NOT EXISTS scores (
-		id SERIAL PRIMARY KEY,
-		user_id INTEGER REFERENCES users(id),
-		username VARCHAR(50) NOT NULL,
-		github_id INTEGER NOT NULL,
-		wpm DECIMAL(6,2) NOT NULL CHECK (wpm >= 0 AND wpm <= 300),
-		accuracy DECIMAL(5,2) NOT NULL CHECK (accuracy >= 0 AND accuracy <= 100),
-		duration INTEGER NOT NULL DEFAULT 60 CHECK (duration = 60),
-		language VARCHAR(20) NOT NULL DEFAULT 'english',
-		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-	);
-
-	-- Indexes for fast leaderboard queries
-	CREATE INDEX IF NOT EXISTS idx_scores_leaderboard 
-	ON scores(wpm DESC, accuracy DESC, created_at DESC) 
-	WHERE accuracy >= 85.0 AND duration = 60;
-	
-	CREATE INDEX IF NOT EXISTS idx_scores_user_rank 
-	ON scores(github_id, created_at DESC);
-	
-	CREATE INDEX IF NOT EXISTS idx_users_github_id 
-	ON users(github_id);
-
-	-- Function to update user updated_at timestamp
-	CREATE OR REPLACE FUNCTION update_user_updated_at()
-	RETURNS TRIGGER AS $$
-	BEGIN
-		NEW.updated_at = CURRENT_TIMESTAMP;
-		RETURN NEW;
-	END;
-	$$ LANGUAGE plpgsql;
-
-	-- Trigger for updated_at
-	DROP TRIGGER IF EXISTS update_user_updated_at_trigger ON users;
-	CREATE TRIGGER update_user_updated_at_trigger
-		BEFORE UPDATE ON users
-		FOR EACH ROW
-		EXECUTE FUNCTION update_user_updated_at();
-	`
-
-	_, err := db.Exec(schema)
-	return err
+	return runMigrations(db)
 }
 
 func (s *APIServer) healthCheck(w http.ResponseWriter, r *http.Request) {
@@ -239,7 +378,8 @@ func (s *APIServer) healthCheck(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"status":     "OK",
 		"timestamp":  time.Now(),
-		"version":    "1.0.0",
+		"version":    ServerVersion,
+		"api_version": APIVersion,
 		"service":    "zentype-server",
 	})
 }
@@ -248,12 +388,13 @@ func (s *APIServer) serverInfo(w http.ResponseWriter, r *http.Request) {
 	// Get some basic stats
 	var totalUsers, totalScores int
 	s.db.QueryRow("SELECT COUNT(*) FROM users").Scan(&totalUsers)
-	s.db.QueryRow("SELECT COUNT(*) FROM scores WHERE accuracy >= $1 AND duration = $2", MinAccuracy, TargetDuration).Scan(&totalScores)
+	s.db.QueryRow("SELECT COUNT(*) FROM scores WHERE accuracy >= $1 AND duration = $2 AND mode = 'standard' AND NOT suspect", MinAccuracy, TargetDuration).Scan(&totalScores)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"service":         "ZenType Leaderboard API",
-		"version":         "1.0.0",
+		"version":         ServerVersion,
+		"api_version":     APIVersion,
 		"min_accuracy":    MinAccuracy,
 		"target_duration": TargetDuration,
 		"total_users":     totalUsers,
@@ -267,8 +408,16 @@ func (s *APIServer) serverInfo(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// CallbackFormatText asks the OAuth callback for the bare token as plain text
+// instead of the HTML success page. GitHub only passes the state through to
+// the callback, so a format chosen on /auth/github is carried in it.
+const CallbackFormatText = "text"
+
 func (s *APIServer) githubAuth(w http.ResponseWriter, r *http.Request) {
 	state := fmt.Sprintf("zentype_%d", time.Now().Unix())
+	if r.URL.Query().Get("format") == CallbackFormatText {
+		state += "_" + CallbackFormatText
+	}
 	url := s.oauthConfig.AuthCodeURL(state, oauth2.AccessTypeOffline)
 w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{
@@ -319,27 +468,35 @@ func (s *APIServer) githubCallback(w http.ResponseWriter, r *http.Request) {
 		username = githubUser.Name
 	}
 
-	// Store/update user in database
+	// Store/update user in database. The GitHub ID never changes but the login
+	// can, so it is refreshed on every sign-in; a display name the user chose
+	// themselves is kept.
 	var userID int
 	err = s.db.QueryRow(`
 		INSERT INTO users (username, github_id, github_login, avatar_url, access_token) 
 		VALUES ($1, $2, $3, $4, $5)
 		ON CONFLICT (github_id) 
 		DO UPDATE SET 
-			username = EXCLUDED.username,
+			username = CASE WHEN users.name_customized THEN users.username ELSE EXCLUDED.username END,
 			github_login = EXCLUDED.github_login,
 			avatar_url = EXCLUDED.avatar_url,
 			access_token = EXCLUDED.access_token,
 			updated_at = CURRENT_TIMESTAMP
-		RETURNING id`,
+		RETURNING id, username`,
 		username, githubUser.ID, githubUser.Login, githubUser.AvatarURL, token.AccessToken,
-	).Scan(&userID)
+	).Scan(&userID, &username)
 
 	if err != nil {
 		http.Error(w, "Failed to store user", http.StatusInternalServerError)
 		return
 	}
 
+	if wantsTextCallback(r) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintln(w, token.AccessToken)
+		return
+	}
+
 	// Return success page with token
 	w.Header().Set("Content-Type", "text/html")
 	fmt.Fprintf(w, `
@@ -457,6 +614,20 @@ func (s *APIServer) githubCallback(w http.ResponseWriter, r *http.Request) {
 	`, githubUser.AvatarURL, username, githubUser.Login, token.AccessToken)
 }
 
+// wantsTextCallback reports whether the callback should answer with the bare
+// token: asked for with ?format=text on the callback or on /auth/github, or
+// by a client that accepts text/plain but not HTML
+func wantsTextCallback(r *http.Request) bool {
+	if r.URL.Query().Get("format") == CallbackFormatText {
+		return true
+	}
+	if strings.HasSuffix(r.URL.Query().Get("state"), "_"+CallbackFormatText) {
+		return true
+	}
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, "text/plain") && !strings.Contains(accept, "text/html")
+}
+
 func (s *APIServer) verifyToken(w http.ResponseWriter, r *http.Request) {
 	token := r.Header.Get("Authorization")
 	if token == "" {
@@ -495,6 +666,20 @@ func (s *APIServer) verifyToken(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(user)
 }
 
+// validIdempotencyKey reports whether key is empty or a short run of letters,
+// digits and dashes, as clients generate
+func validIdempotencyKey(key string) bool {
+	if len(key) > MaxIdempotencyKeyLength {
+		return false
+	}
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *APIServer) submitScore(w http.ResponseWriter, r *http.Request) {
 	// Verify authentication
 	token := r.Header.Get("Authorization")
@@ -508,10 +693,11 @@ func (s *APIServer) submitScore(w http.ResponseWriter, r *http.Request) {
 	var userID int
 	var username string
 	var githubID int
+	var githubLogin string
 	err := s.db.QueryRow(`
-		SELECT id, username, github_id FROM users WHERE access_token = $1`,
+		SELECT id, username, github_id, github_login FROM users WHERE access_token = $1`,
 		token,
-	).Scan(&userID, &username, &githubID)
+	).Scan(&userID, &username, &githubID, &githubLogin)
 
 	if err != nil {
 		http.Error(w, "Invalid token", http.StatusUnauthorized)
@@ -546,15 +732,111 @@ func (s *APIServer) submitScore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Insert score
+	// Daily challenge scores belong to the board for their date; the client sends
+	// the date it started on, so allow yesterday for tests that span midnight UTC
+	var challengeDate interface{} // NULL for standard scores
+	switch entry.Mode {
+	case "", ModeStandard:
+		entry.Mode = ModeStandard
+		entry.ChallengeDate = ""
+	case ModeDaily:
+		date, err := time.Parse(DateLayout, entry.ChallengeDate)
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		if err != nil || date.After(today) || date.Before(today.AddDate(0, 0, -1)) {
+			http.Error(w, "Daily challenge date must be today or yesterday (UTC)", http.StatusBadRequest)
+			return
+		}
+		challengeDate = entry.ChallengeDate
+	default:
+		http.Error(w, fmt.Sprintf("Unknown mode %q", entry.Mode), http.StatusBadRequest)
+		return
+	}
+
+	// The generator details only mean something together with a seed
+	if entry.Seed == 0 {
+		entry.GeneratorVersion = 0
+		entry.WordDist = ""
+	}
+	if entry.GeneratorVersion < 0 || entry.GeneratorVersion > MaxGeneratorVersion {
+		http.Error(w, "Invalid generator version", http.StatusBadRequest)
+		return
+	}
+	if entry.WordDist != "" && entry.WordDist != "uniform" && entry.WordDist != "frequency" {
+		http.Error(w, "Invalid word distribution", http.StatusBadRequest)
+		return
+	}
+
+	if len(entry.Intervals) > MaxTimingSamples {
+		http.Error(w, "Too many timing samples", http.StatusBadRequest)
+		return
+	}
+
+	if !validIdempotencyKey(entry.IdempotencyKey) {
+		http.Error(w, "Invalid idempotency key", http.StatusBadRequest)
+		return
+	}
+
+	// A score sent late keeps the time it was played, unless that is implausible
+	var playedAt interface{} // NULL stores the time received
+	if entry.PlayedAt != nil {
+		now := time.Now()
+		if age := now.Sub(*entry.PlayedAt); age <= MaxPlayedAtAge && age >= -MaxPlayedAtSkew {
+			playedAt = entry.PlayedAt.UTC()
+		}
+	}
+
+	// Timing heuristics catch pasted or scripted input that stays under the WPM ceiling
+	suspect := false
+	if s.antiCheatMode != AntiCheatOff {
+		if bad, reason := suspiciousTiming(entry); bad {
+			log.Printf("Suspicious score from %s (%.0f WPM): %s", username, entry.WPM, reason)
+			if s.antiCheatMode == AntiCheatReject {
+				http.Error(w, "Score rejected: typing timing looks automated", http.StatusUnprocessableEntity)
+				return
+			}
+			suspect = true
+		}
+	}
+	entry.Intervals = nil // Only needed for the checks above; not echoed back
+	entry.PlayedAt = nil  // Stored as created_at
+
+	// Look up the user's previous best before inserting so we can report a personal best
+	var previousBest float64
+	err = s.db.QueryRow(`
+		SELECT COALESCE(MAX(wpm), 0)
+		FROM scores
+		WHERE github_id = $1 AND accuracy >= $2 AND duration = $3 AND language = $4
+			AND mode = $5 AND challenge_date IS NOT DISTINCT FROM $6::date AND NOT suspect`,
+		githubID, MinAccuracy, TargetDuration, entry.Language, entry.Mode, challengeDate,
+	).Scan(&previousBest)
+	if err != nil {
+		log.Printf("Error getting previous best: %v", err)
+		previousBest = 0
+	}
+
+	// Insert score. A score whose idempotency key is already stored for this
+	// user was sent before (e.g. the response was lost), so the stored score
+	// is returned instead of adding it again; its WPM is already in
+	// previousBest, so it is never reported as a new personal best.
 	var scoreID int
 	var createdAt time.Time
 	err = s.db.QueryRow(`
-		INSERT INTO scores (user_id, username, github_id, wpm, accuracy, duration, language) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7) 
+		INSERT INTO scores (user_id, username, github_id, wpm, accuracy, duration, language, mode, challenge_date,
+			seed, generator_version, word_dist, suspect, idempotency_key, created_at) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10::bigint, 0), NULLIF($11::integer, 0), NULLIF($12, ''), $13,
+			NULLIF($14, ''), COALESCE($15::timestamptz, CURRENT_TIMESTAMP)) 
+		ON CONFLICT (github_id, idempotency_key) WHERE idempotency_key IS NOT NULL DO NOTHING
 		RETURNING id, created_at`,
-		userID, username, githubID, entry.WPM, entry.Accuracy, entry.Duration, entry.Language,
+		userID, username, githubID, entry.WPM, entry.Accuracy, entry.Duration, entry.Language, entry.Mode, challengeDate,
+		entry.Seed, entry.GeneratorVersion, entry.WordDist, suspect, entry.IdempotencyKey, playedAt,
 	).Scan(&scoreID, &createdAt)
+	if err == sql.ErrNoRows {
+		log.Printf("Duplicate score submission from %s (key %s)", username, entry.IdempotencyKey)
+		err = s.db.QueryRow(`
+			SELECT id, created_at FROM scores WHERE github_id = $1 AND idempotency_key = $2`,
+			githubID, entry.IdempotencyKey,
+		).Scan(&scoreID, &createdAt)
+	}
 
 	if err != nil {
 		log.Printf("Error inserting score: %v", err)
@@ -562,34 +844,24 @@ func (s *APIServer) submitScore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Calculate current rank based on the new score
-	var rank int
-	err = s.db.QueryRow(`
-		WITH user_best_scores AS (
-			SELECT 
-				github_id,
-				CASE 
-					WHEN github_id = $4 THEN GREATEST(MAX(wpm), $5)
-					ELSE MAX(wpm)
-				END as best_wpm,
-				CASE 
-					WHEN github_id = $4 AND GREATEST(MAX(wpm), $5) = $5 THEN $6
-					WHEN github_id = $4 AND GREATEST(MAX(wpm), $5) > $5 THEN MAX(CASE WHEN wpm = MAX(wpm) THEN accuracy END)
-					ELSE MAX(CASE WHEN wpm = MAX(wpm) THEN accuracy END)
-				END as best_accuracy
-			FROM scores 
-			WHERE accuracy >= $1 AND duration = $2 AND language = $3
-			GROUP BY github_id
-		)
-		SELECT COUNT(*) + 1
-		FROM user_best_scores
-		WHERE best_wpm > $5 OR (best_wpm = $5 AND best_accuracy > $6)`,
-		MinAccuracy, TargetDuration, entry.Language, githubID, entry.WPM, entry.Accuracy,
-	).Scan(&rank)
-
-	if err != nil {
+	// The new score is stored, so the user's rank is their place on the board
+	// the score was submitted to, computed exactly as the board computes it
+	boardLanguage := entry.Language
+	if entry.Mode == ModeDaily {
+		boardLanguage = "" // Daily boards rank every language together
+	}
+	rank := 0
+	ranked, err := s.userRankEntry(rankFilter{
+		Mode:          entry.Mode,
+		Language:      boardLanguage,
+		ChallengeDate: challengeDate,
+		PublicOnly:    true,
+		Viewer:        githubID,
+	}, githubID)
+	if err == nil {
+		rank = ranked.Rank
+	} else if err != sql.ErrNoRows {
 		log.Printf("Error calculating rank: %v", err)
-		rank = 0 // Default if rank calculation fails
 	}
 
 	// Log the score submission
@@ -600,12 +872,21 @@ func (s *APIServer) submitScore(w http.ResponseWriter, r *http.Request) {
 		ID:        scoreID,
 		Username:  username,
 		GitHubID:  githubID,
+		GitHubLogin: githubLogin,
 		WPM:       entry.WPM,
 		Accuracy:  entry.Accuracy,
 		Duration:  entry.Duration,
 		Language:  entry.Language,
 		CreatedAt: createdAt,
 		Rank:      rank,
+		Mode:      entry.Mode,
+		ChallengeDate: entry.ChallengeDate,
+		Seed:      entry.Seed,
+		GeneratorVersion: entry.GeneratorVersion,
+		WordDist:  entry.WordDist,
+
+		PersonalBest: !suspect && entry.WPM > previousBest,
+		PreviousBest: previousBest,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -618,66 +899,18 @@ func (s *APIServer) getLeaderboard(w http.ResponseWriter, r *http.Request) {
 	if language == "" {
 		language = "english"
 	}
+	limit := parseLimit(r)
 
-	// Get top 10 users (best score per user, ties broken by accuracy)
-	query := `
-		WITH user_best AS (
-			SELECT 
-				username,
-				github_id,
-				MAX(wpm) as best_wpm
-			FROM scores 
-			WHERE accuracy >= $1 AND duration = $2 AND language = $3
-			GROUP BY username, github_id
-		),
-		user_details AS (
-			SELECT DISTINCT ON (s.username, s.github_id)
-				s.username,
-				s.github_id,
-				ub.best_wpm,
-				s.accuracy as best_accuracy,
-				s.created_at as score_date
-			FROM scores s
-			JOIN user_best ub ON s.username = ub.username AND s.github_id = ub.github_id AND s.wpm = ub.best_wpm
-			WHERE s.accuracy >= $1 AND s.duration = $2 AND s.language = $3
-			ORDER BY s.username, s.github_id, s.accuracy DESC, s.created_at ASC
-		)
-		SELECT 
-			username,
-			github_id,
-			best_wpm,
-			best_accuracy,
-			score_date,
-			ROW_NUMBER() OVER (ORDER BY best_wpm DESC, best_accuracy DESC, score_date ASC) as rank
-		FROM user_details
-		ORDER BY rank
-		LIMIT 10`
-
-	rows, err := s.db.Query(query, MinAccuracy, TargetDuration, language)
+	// Top public users by their best score
+	board := rankFilter{Mode: ModeStandard, Language: language, PublicOnly: true}
+	entries, err := s.queryRanked(board, `ORDER BY rank LIMIT $9`, limit)
 	if err != nil {
 		log.Printf("Error getting leaderboard: %v", err)
 		http.Error(w, "Database error", http.StatusInternalServerError)
 		return
 	}
-	defer rows.Close()
 
-	var entries []LeaderboardEntry
-	for rows.Next() {
-		var entry LeaderboardEntry
-		err := rows.Scan(
-			&entry.Username, &entry.GitHubID, &entry.WPM, 
-			&entry.Accuracy, &entry.CreatedAt, &entry.Rank,
-		)
-		if err != nil {
-			log.Printf("Error scanning leaderboard row: %v", err)
-			continue
-		}
-		entry.Duration = TargetDuration
-		entry.Language = language
-		entries = append(entries, entry)
-	}
-
-	// If user is authenticated and not in top 10, get their entry separately
+	// If user is authenticated and not in the returned entries, get their entry separately
 	var userEntry *LeaderboardEntry
 	token := r.Header.Get("Authorization")
 	if token != "" {
@@ -694,53 +927,13 @@ func (s *APIServer) getLeaderboard(w http.ResponseWriter, r *http.Request) {
 				}
 			}
 			
-			// If not in top 10, get user's entry
+			// If not in top 10, get user's entry, ranked even if their profile is private
 			if !userInTop10 {
-				userQuery := `
-					WITH user_best AS (
-						SELECT 
-							username,
-							github_id,
-							MAX(wpm) as best_wpm
-						FROM scores 
-						WHERE accuracy >= $1 AND duration = $2 AND language = $3 AND github_id = $4
-						GROUP BY username, github_id
-					),
-					user_details AS (
-						SELECT DISTINCT ON (s.username, s.github_id)
-							s.username,
-							s.github_id,
-							ub.best_wpm,
-							s.accuracy as best_accuracy,
-							s.created_at as score_date
-						FROM scores s
-						JOIN user_best ub ON s.username = ub.username AND s.github_id = ub.github_id AND s.wpm = ub.best_wpm
-						WHERE s.accuracy >= $1 AND s.duration = $2 AND s.language = $3 AND s.github_id = $4
-						ORDER BY s.username, s.github_id, s.accuracy DESC, s.created_at ASC
-					),
-					all_users AS (
-						SELECT 
-							username,
-							github_id,
-							MAX(wpm) as best_wpm
-						FROM scores 
-						WHERE accuracy >= $1 AND duration = $2 AND language = $3
-						GROUP BY username, github_id
-					)
-					SELECT 
-						ud.username,
-						ud.github_id,
-						ud.best_wpm,
-						ud.best_accuracy,
-						ud.score_date,
-						(SELECT COUNT(*) + 1 FROM all_users au WHERE au.best_wpm > ud.best_wpm) as rank
-					FROM user_details ud`
-				
-				var entry LeaderboardEntry
-				err = s.db.QueryRow(userQuery, MinAccuracy, TargetDuration, language, githubID).Scan(
-					&entry.Username, &entry.GitHubID, &entry.WPM, &entry.Accuracy, &entry.CreatedAt, &entry.Rank)
-				if err == nil {
+				board.Viewer = githubID
+				if entry, err := s.userRankEntry(board, githubID); err == nil {
 					userEntry = &entry
+				} else if err != sql.ErrNoRows {
+					log.Printf("Error getting user leaderboard entry: %v", err)
 				}
 			}
 		}
@@ -758,6 +951,106 @@ func (s *APIServer) getLeaderboard(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+func (s *APIServer) getDailyLeaderboard(w http.ResponseWriter, r *http.Request) {
+	date := r.URL.Query().Get("date")
+	if date == "" {
+		date = time.Now().UTC().Format(DateLayout)
+	}
+	if _, err := time.Parse(DateLayout, date); err != nil {
+		http.Error(w, "Invalid date, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	limit := parseLimit(r)
+
+	// Best daily score per public user for the date, across all languages
+	board := rankFilter{Mode: ModeDaily, ChallengeDate: date, PublicOnly: true}
+	entries, err := s.queryRanked(board, `ORDER BY rank LIMIT $9`, limit)
+	if err != nil {
+		log.Printf("Error getting daily leaderboard: %v", err)
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+	for i := range entries {
+		entries[i].ChallengeDate = date
+	}
+
+	response := struct {
+		Date    string             `json:"date"`
+		Entries []LeaderboardEntry `json:"entries"`
+	}{
+		Date:    date,
+		Entries: entries,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (s *APIServer) getActiveLeaderboard(w http.ResponseWriter, r *http.Request) {
+	language := r.URL.Query().Get("language")
+	if language == "" {
+		language = "english"
+	}
+	limit := parseLimit(r)
+
+	// Rank public users by qualifying tests, then by their longest run of
+	// consecutive days with at least one qualifying test
+	rows, err := s.db.Query(`
+		WITH qualified AS (
+			SELECT github_id, created_at
+			FROM scores
+			WHERE accuracy >= $1 AND duration = $2 AND mode = 'standard' AND language = $3 AND NOT suspect
+				AND github_id IN (SELECT github_id FROM users WHERE public)
+		),
+		counts AS (
+			SELECT q.github_id, u.username, u.github_login, COUNT(*) as qualified_scores
+			FROM qualified q
+			JOIN users u ON u.github_id = q.github_id
+			GROUP BY q.github_id, u.username, u.github_login
+		),
+		days AS (
+			SELECT DISTINCT github_id, created_at::date as day
+			FROM qualified
+		),
+		islands AS (
+			SELECT github_id, day - (ROW_NUMBER() OVER (PARTITION BY github_id ORDER BY day))::int as grp
+			FROM days
+		),
+		streaks AS (
+			SELECT github_id, MAX(length) as longest_streak
+			FROM (SELECT github_id, COUNT(*) as length FROM islands GROUP BY github_id, grp) runs
+			GROUP BY github_id
+		)
+		SELECT
+			c.username, c.github_id, c.github_login, c.qualified_scores, s.longest_streak,
+			ROW_NUMBER() OVER (ORDER BY c.qualified_scores DESC, s.longest_streak DESC, c.username ASC) as rank
+		FROM counts c
+		JOIN streaks s ON s.github_id = c.github_id
+		ORDER BY rank
+		LIMIT $4`,
+		MinAccuracy, TargetDuration, language, limit,
+	)
+	if err != nil {
+		log.Printf("Error getting active leaderboard: %v", err)
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	entries := []ActiveEntry{}
+	for rows.Next() {
+		var entry ActiveEntry
+		if err := rows.Scan(&entry.Username, &entry.GitHubID, &entry.GitHubLogin, &entry.QualifiedScores, &entry.LongestStreak, &entry.Rank); err != nil {
+			log.Printf("Error scanning active leaderboard row: %v", err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"entries": entries})
+}
+
 func (s *APIServer) getUserRank(w http.ResponseWriter, r *http.Request) {
 	// Verify authentication
 	token := r.Header.Get("Authorization")
@@ -785,19 +1078,31 @@ func (s *APIServer) getUserRank(w http.ResponseWriter, r *http.Request) {
 		language = "english"
 	}
 
+	userStats, err := s.loadUserStats(githubID, username, language)
+	if err != nil {
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(userStats)
+}
+
+// loadUserStats computes a user's best score, score counts and rank for a language
+func (s *APIServer) loadUserStats(githubID int, username, language string) (UserStats, error) {
 	// Get user's best score and rank
 	var userStats UserStats
 	userStats.Username = username
 	userStats.GitHubID = githubID
 
 	// Get user's best score - simplified query
-	err = s.db.QueryRow(`
+	err := s.db.QueryRow(`
 		SELECT 
 			COALESCE(MAX(wpm), 0) as best_wpm,
 			COUNT(*) as total_scores,
 			COUNT(CASE WHEN accuracy >= $1 THEN 1 END) as qualified_scores
 		FROM scores 
-		WHERE github_id = $2 AND duration = $3 AND language = $4`,
+		WHERE github_id = $2 AND duration = $3 AND mode = 'standard' AND language = $4 AND NOT suspect`,
 		MinAccuracy, githubID, TargetDuration, language,
 	).Scan(&userStats.BestWPM, &userStats.TotalScores, &userStats.QualifiedScores)
 	
@@ -806,7 +1111,7 @@ func (s *APIServer) getUserRank(w http.ResponseWriter, r *http.Request) {
 		err2 := s.db.QueryRow(`
 			SELECT accuracy 
 			FROM scores 
-			WHERE github_id = $1 AND duration = $2 AND language = $3 AND wpm = $4
+			WHERE github_id = $1 AND duration = $2 AND mode = 'standard' AND language = $3 AND wpm = $4 AND NOT suspect
 			ORDER BY accuracy DESC, created_at ASC
 			LIMIT 1`,
 			githubID, TargetDuration, language, userStats.BestWPM,
@@ -817,39 +1122,210 @@ func (s *APIServer) getUserRank(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil && err != sql.ErrNoRows {
-		http.Error(w, "Database error", http.StatusInternalServerError)
+		return userStats, err
+	}
+
+	// Rank comes from the shared board query, which also picks the best
+	// qualifying score, so it always matches the leaderboard
+	if userStats.QualifiedScores > 0 {
+		board := rankFilter{Mode: ModeStandard, Language: language, PublicOnly: true, Viewer: githubID}
+		best, err := s.userRankEntry(board, githubID)
+		if err != nil {
+			if err != sql.ErrNoRows {
+				log.Printf("Error calculating rank: %v", err)
+			}
+			return userStats, nil
+		}
+		userStats.BestWPM = best.WPM
+		userStats.BestAccuracy = best.Accuracy
+		userStats.Rank = best.Rank
+
+		// Find the best WPM of the user ranked immediately above
+		if best.Rank > 1 {
+			above, err := s.queryRanked(board, `WHERE rank = $9`, best.Rank-1)
+			if err == nil && len(above) > 0 {
+				userStats.NextRankWPM = above[0].WPM
+			}
+		}
+	}
+	return userStats, nil
+}
+
+// getScore returns the public fields of a single score so runs can be linked and verified
+func (s *APIServer) getScore(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid score ID", http.StatusBadRequest)
 		return
 	}
 
-	// Calculate rank only if user has qualifying scores
-	if userStats.QualifiedScores > 0 && userStats.BestWPM > 0 {
-		// Simple rank calculation: count users with better scores
-		err = s.db.QueryRow(`
-			WITH user_best AS (
-				SELECT 
-					github_id,
-					MAX(wpm) as best_wpm,
-					MAX(accuracy) as best_accuracy
-				FROM scores 
-				WHERE accuracy >= $1 AND duration = $2 AND language = $3
-				GROUP BY github_id
-			)
-			SELECT COUNT(*) + 1
-			FROM user_best
-			WHERE best_wpm > $4 OR (best_wpm = $4 AND best_accuracy > $5)`,
-			MinAccuracy, TargetDuration, language, userStats.BestWPM, userStats.BestAccuracy,
-		).Scan(&userStats.Rank)
+	var entry LeaderboardEntry
+	var challengeDate sql.NullTime
+	var seed sql.NullInt64
+	var generatorVersion sql.NullInt64
+	var wordDist sql.NullString
+	err = s.db.QueryRow(`
+		SELECT s.id, u.username, s.github_id, u.github_login, s.wpm, s.accuracy, s.duration, s.language,
+			s.created_at, s.mode, s.challenge_date, s.seed, s.generator_version, s.word_dist
+		FROM scores s
+		JOIN users u ON u.github_id = s.github_id
+		WHERE s.id = $1 AND u.public`,
+		id,
+	).Scan(&entry.ID, &entry.Username, &entry.GitHubID, &entry.GitHubLogin, &entry.WPM, &entry.Accuracy, &entry.Duration,
+		&entry.Language, &entry.CreatedAt, &entry.Mode, &challengeDate, &seed, &generatorVersion, &wordDist)
 
-		if err != nil {
-			userStats.Rank = 0
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Score not found", http.StatusNotFound)
+		} else {
+			log.Printf("Error getting score %d: %v", id, err)
+			http.Error(w, "Database error", http.StatusInternalServerError)
 		}
-	} else {
-		userStats.Rank = 0 // Not qualified for leaderboard
+		return
+	}
+
+	if challengeDate.Valid {
+		entry.ChallengeDate = challengeDate.Time.Format(DateLayout)
+	}
+	entry.Seed = seed.Int64
+	entry.GeneratorVersion = int(generatorVersion.Int64)
+	entry.WordDist = wordDist.String
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entry)
+}
+
+func (s *APIServer) getPublicProfile(w http.ResponseWriter, r *http.Request) {
+	login := mux.Vars(r)["login"]
+
+	var githubID int
+	var username string
+	err := s.db.QueryRow(`
+		SELECT github_id, username FROM users WHERE LOWER(github_login) = LOWER($1) AND public`,
+		login,
+	).Scan(&githubID, &username)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "User not found", http.StatusNotFound)
+		} else {
+			http.Error(w, "Database error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	language := r.URL.Query().Get("language")
+	if language == "" {
+		language = "english"
 	}
+
+	userStats, err := s.loadUserStats(githubID, username, language)
+	if err != nil {
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(userStats)
 }
 
+func (s *APIServer) setVisibility(w http.ResponseWriter, r *http.Request) {
+	// Verify authentication
+	token := r.Header.Get("Authorization")
+	if token == "" {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	token = strings.TrimPrefix(token, "Bearer ")
+
+	var request struct {
+		Public bool `json:"public"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	result, err := s.db.Exec(`UPDATE users SET public = $1 WHERE access_token = $2`, request.Public, token)
+	if err != nil {
+		log.Printf("Error updating visibility: %v", err)
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		http.Error(w, "Invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]bool{"public": request.Public})
+}
+
+// validDisplayName checks a trimmed display name. Every character must be
+// printable, so a name can't hide control codes, line breaks or invisible
+// characters on other players' boards.
+func validDisplayName(name string) error {
+	if utf8.RuneCountInString(name) > MaxUsernameLength {
+		return fmt.Errorf("Username must be at most %d characters", MaxUsernameLength)
+	}
+	for _, r := range name {
+		if !unicode.IsPrint(r) {
+			return fmt.Errorf("Username can't contain control or invisible characters")
+		}
+	}
+	return nil
+}
+
+// setDisplayName sets the name shown for the user on boards. A name set here
+// survives later sign-ins; an empty name goes back to the GitHub login until
+// the next sign-in refreshes it from GitHub.
+func (s *APIServer) setDisplayName(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("Authorization")
+	if token == "" {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	token = strings.TrimPrefix(token, "Bearer ")
+
+	var request struct {
+		Username string `json:"username"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	name := strings.TrimSpace(request.Username)
+	if err := validDisplayName(name); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var username string
+	err := s.db.QueryRow(`
+		UPDATE users SET
+			username = CASE WHEN $1::text = '' THEN github_login ELSE $1::text END,
+			name_customized = $1::text <> '',
+			updated_at = CURRENT_TIMESTAMP
+		WHERE access_token = $2
+		RETURNING username`,
+		name, token,
+	).Scan(&username)
+	if err == sql.ErrNoRows {
+		http.Error(w, "Invalid token", http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		log.Printf("Error updating display name: %v", err)
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"username": username})
+}
+
 func (s *APIServer) getGlobalStats(w http.ResponseWriter, r *http.Request) {
 	var stats struct {
 		TotalUsers      int     `json:"total_users"`
@@ -864,12 +1340,12 @@ func (s *APIServer) getGlobalStats(w http.ResponseWriter, r *http.Request) {
 	// Get basic stats
 	err := s.db.QueryRow(`
 		SELECT 
-			(SELECT COUNT(DISTINCT github_id) FROM scores WHERE accuracy >= $1 AND duration = $2) as total_users,
-			(SELECT COUNT(*) FROM scores WHERE accuracy >= $1 AND duration = $2) as qualified_scores,
-			(SELECT COUNT(*) FROM scores WHERE duration = $2) as total_scores,
-			COALESCE((SELECT MAX(wpm) FROM scores WHERE accuracy >= $1 AND duration = $2), 0) as highest_wpm,
-			COALESCE((SELECT AVG(wpm) FROM scores WHERE accuracy >= $1 AND duration = $2), 0) as avg_wpm,
-			COALESCE((SELECT AVG(accuracy) FROM scores WHERE accuracy >= $1 AND duration = $2), 0) as avg_accuracy`,
+			(SELECT COUNT(DISTINCT github_id) FROM scores WHERE accuracy >= $1 AND duration = $2 AND mode = 'standard' AND NOT suspect) as total_users,
+			(SELECT COUNT(*) FROM scores WHERE accuracy >= $1 AND duration = $2 AND mode = 'standard' AND NOT suspect) as qualified_scores,
+			(SELECT COUNT(*) FROM scores WHERE duration = $2 AND mode = 'standard' AND NOT suspect) as total_scores,
+			COALESCE((SELECT MAX(wpm) FROM scores WHERE accuracy >= $1 AND duration = $2 AND mode = 'standard' AND NOT suspect), 0) as highest_wpm,
+			COALESCE((SELECT AVG(wpm) FROM scores WHERE accuracy >= $1 AND duration = $2 AND mode = 'standard' AND NOT suspect), 0) as avg_wpm,
+			COALESCE((SELECT AVG(accuracy) FROM scores WHERE accuracy >= $1 AND duration = $2 AND mode = 'standard' AND NOT suspect), 0) as avg_accuracy`,
 		MinAccuracy, TargetDuration,
 	).Scan(&stats.TotalUsers, &stats.QualifiedScores, &stats.TotalScores, 
 		&stats.HighestWPM, &stats.AverageWPM, &stats.AverageAccuracy)
@@ -884,7 +1360,7 @@ func (s *APIServer) getGlobalStats(w http.ResponseWriter, r *http.Request) {
 	err = s.db.QueryRow(`
 		SELECT username 
 		FROM scores 
-		WHERE accuracy >= $1 AND duration = $2 AND wpm = $3
+		WHERE accuracy >= $1 AND duration = $2 AND mode = 'standard' AND wpm = $3 AND NOT suspect
 		ORDER BY accuracy DESC, created_at ASC 
 		LIMIT 1`,
 		MinAccuracy, TargetDuration, stats.HighestWPM,
@@ -897,3 +1373,141 @@ func (s *APIServer) getGlobalStats(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(stats)
 }
+
+// getLanguageStats compares languages by player count, average speed and accuracy
+func (s *APIServer) getLanguageStats(w http.ResponseWriter, r *http.Request) {
+	const cacheKey = "all"
+	if cached, ok := s.languageCache.get(cacheKey); ok {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(cached)
+		return
+	}
+
+	rows, err := s.db.Query(`
+		SELECT language, COUNT(DISTINCT github_id), COUNT(*),
+			AVG(wpm), AVG(accuracy), MAX(wpm)
+		FROM scores
+		WHERE accuracy >= $1 AND duration = $2 AND mode = 'standard' AND NOT suspect
+		GROUP BY language
+		ORDER BY COUNT(DISTINCT github_id) DESC, language`,
+		MinAccuracy, TargetDuration,
+	)
+	if err != nil {
+		log.Printf("Error getting language stats: %v", err)
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	languages := []LanguageStats{}
+	for rows.Next() {
+		var stats LanguageStats
+		if err := rows.Scan(&stats.Language, &stats.Users, &stats.Scores,
+			&stats.AverageWPM, &stats.AverageAccuracy, &stats.BestWPM); err != nil {
+			log.Printf("Error scanning language stats row: %v", err)
+			continue
+		}
+		languages = append(languages, stats)
+	}
+
+	s.languageCache.set(cacheKey, languages)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(languages)
+}
+
+func (s *APIServer) getDistribution(w http.ResponseWriter, r *http.Request) {
+	language := r.URL.Query().Get("language")
+	if language == "" {
+		language = "english"
+	}
+
+	if cached, ok := s.distributionCache.get(language); ok {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(cached)
+		return
+	}
+
+	// Count users per bucket of their best qualifying WPM; private users are
+	// anonymous here, so everyone on the board counts
+	board := rankFilter{Mode: ModeStandard, Language: language}
+	rows, err := s.db.Query(rankedQuery(`
+		SELECT FLOOR(wpm / $9)::int as bucket, COUNT(*)
+		FROM ranked
+		GROUP BY bucket
+		ORDER BY bucket`), board.args(DistributionBucketSize)...)
+	if err != nil {
+		log.Printf("Error getting distribution: %v", err)
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int)
+	maxBucket := -1
+	for rows.Next() {
+		var bucket, count int
+		if err := rows.Scan(&bucket, &count); err != nil {
+			log.Printf("Error scanning distribution row: %v", err)
+			continue
+		}
+		counts[bucket] = count
+		if bucket > maxBucket {
+			maxBucket = bucket
+		}
+	}
+
+	// Fill in empty buckets so clients can draw a continuous histogram
+	distribution := WPMDistribution{
+		Language:   language,
+		BucketSize: DistributionBucketSize,
+		Buckets:    []WPMBucket{},
+	}
+	for bucket := 0; bucket <= maxBucket; bucket++ {
+		distribution.Buckets = append(distribution.Buckets, WPMBucket{
+			MinWPM: bucket * DistributionBucketSize,
+			MaxWPM: (bucket + 1) * DistributionBucketSize,
+			Count:  counts[bucket],
+		})
+		distribution.TotalUsers += counts[bucket]
+	}
+
+	s.distributionCache.set(language, distribution)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(distribution)
+}
+
+func (s *APIServer) getActivity(w http.ResponseWriter, r *http.Request) {
+	limit := parseLimit(r)
+
+	// Most recent qualifying scores from public users, across all languages
+	rows, err := s.db.Query(`
+		SELECT u.username, u.github_login, s.wpm, s.accuracy, s.language, s.created_at
+		FROM scores s
+		JOIN users u ON u.github_id = s.github_id
+		WHERE s.accuracy >= $1 AND s.duration = $2 AND s.mode = 'standard' AND u.public AND NOT s.suspect
+		ORDER BY s.created_at DESC
+		LIMIT $3`,
+		MinAccuracy, TargetDuration, limit,
+	)
+	if err != nil {
+		log.Printf("Error getting activity: %v", err)
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	entries := []ActivityEntry{}
+	for rows.Next() {
+		var entry ActivityEntry
+		if err := rows.Scan(&entry.Username, &entry.GitHubLogin, &entry.WPM, &entry.Accuracy, &entry.Language, &entry.CreatedAt); err != nil {
+			log.Printf("Error scanning activity row: %v", err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"entries": entries})
+}
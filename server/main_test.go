@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidDisplayName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{name: "", valid: true}, // Goes back to the GitHub login
+		{name: "Ada Lovelace", valid: true},
+		{name: "山田 太郎", valid: true},
+		{name: "café 🙂", valid: true},
+		{name: strings.Repeat("x", MaxUsernameLength), valid: true},
+		{name: strings.Repeat("x", MaxUsernameLength+1), valid: false},
+		{name: "line\nbreak", valid: false},
+		{name: "tab\there", valid: false},
+		{name: "esc\x1b[31mred", valid: false},
+		{name: "zero\u200bwidth", valid: false},
+		{name: "right\u202eleft", valid: false},
+	}
+
+	for _, tt := range tests {
+		if err := validDisplayName(tt.name); (err == nil) != tt.valid {
+			t.Errorf("validDisplayName(%q) = %v, want valid: %v", tt.name, err, tt.valid)
+		}
+	}
+}
+
+func TestValidIdempotencyKey(t *testing.T) {
+	for key, valid := range map[string]bool{
+		"":                                 true,
+		"3f2a9c0d4b5e6f708192a3b4c5d6e7f8": true,
+		"retry-1":                          true,
+		strings.Repeat("a", MaxIdempotencyKeyLength+1): false,
+		"key with spaces":             false,
+		"key'; DROP TABLE scores; --": false,
+	} {
+		if got := validIdempotencyKey(key); got != valid {
+			t.Errorf("validIdempotencyKey(%q) = %v, want %v", key, got, valid)
+		}
+	}
+}
+
+func TestDuplicateSubmissionStoredOnce(t *testing.T) {
+	s := testServer(t)
+	githubID := 2100000000 + int(time.Now().UnixNano()%1000000)
+	token := testUser(t, s, githubID)
+
+	playedAt := time.Now().Add(-2 * time.Hour).UTC().Truncate(time.Second)
+	body, _ := json.Marshal(LeaderboardEntry{
+		WPM: 70, Accuracy: 95, Duration: TargetDuration, Language: "english",
+		PlayedAt: &playedAt, IdempotencyKey: "test-duplicate",
+	})
+
+	var first, second LeaderboardEntry
+	serve(t, s.submitScore, httptest.NewRequest("POST", "/api/scores", bytes.NewReader(body)), token, &first)
+	serve(t, s.submitScore, httptest.NewRequest("POST", "/api/scores", bytes.NewReader(body)), token, &second)
+
+	if first.ID != second.ID {
+		t.Errorf("resubmitting stored score %d as %d, want the same score", first.ID, second.ID)
+	}
+	if second.PersonalBest {
+		t.Error("a resubmitted score was reported as a new personal best")
+	}
+	if !first.CreatedAt.Equal(playedAt) {
+		t.Errorf("created_at = %v, want the time played, %v", first.CreatedAt, playedAt)
+	}
+
+	var count int
+	s.db.QueryRow(`SELECT COUNT(*) FROM scores WHERE github_id = $1`, githubID).Scan(&count)
+	if count != 1 {
+		t.Errorf("%d scores stored, want 1", count)
+	}
+}
+
+func TestSubmissionWithoutIntervalsCounts(t *testing.T) {
+	s := testServer(t)
+	s.antiCheatMode = AntiCheatFlag
+	githubID := 2200000000 + int(time.Now().UnixNano()%1000000)
+	token := testUser(t, s, githubID)
+
+	// Older clients don't send key press timing at all
+	body, _ := json.Marshal(LeaderboardEntry{WPM: 70, Accuracy: 95, Duration: TargetDuration, Language: "english"})
+	var submitted LeaderboardEntry
+	serve(t, s.submitScore, httptest.NewRequest("POST", "/api/scores", bytes.NewReader(body)), token, &submitted)
+
+	var suspect bool
+	if err := s.db.QueryRow(`SELECT suspect FROM scores WHERE id = $1`, submitted.ID).Scan(&suspect); err != nil {
+		t.Fatal(err)
+	}
+	if suspect {
+		t.Error("a score sent without intervals was flagged as suspect")
+	}
+	if submitted.Rank == 0 {
+		t.Error("a score sent without intervals was left off the board")
+	}
+}
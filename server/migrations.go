@@ -0,0 +1,188 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// migration is a single, ordered schema change. Versions must be unique and
+// increasing; a migration is never edited once it has shipped — add a new one.
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// migrations lists every schema change in the order it must be applied
+var migrations = []migration{
+	{
+		version: 1,
+		name:    "initial_schema",
+		// Written idempotently so databases created before migrations existed upgrade cleanly
+		sql: `
+	-- Users table with GitHub integration
+	CREATE TABLE IF NOT EXISTS users (
+		id SERIAL PRIMARY KEY,
+		username VARCHAR(50) NOT NULL,
+		github_id INTEGER UNIQUE NOT NULL,
+		github_login VARCHAR(50) NOT NULL,
+		avatar_url TEXT,
+		access_token TEXT,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+
+	-- Scores table for 60-second tests only
+	CREATE TABLE IF NOT EXISTS scores (
+		id SERIAL PRIMARY KEY,
+		user_id INTEGER REFERENCES users(id),
+		username VARCHAR(50) NOT NULL,
+		github_id INTEGER NOT NULL,
+		wpm DECIMAL(6,2) NOT NULL CHECK (wpm >= 0 AND wpm <= 300),
+		accuracy DECIMAL(5,2) NOT NULL CHECK (accuracy >= 0 AND accuracy <= 100),
+		duration INTEGER NOT NULL DEFAULT 60 CHECK (duration = 60),
+		language VARCHAR(20) NOT NULL DEFAULT 'english',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+
+	-- Indexes for fast leaderboard queries
+	CREATE INDEX IF NOT EXISTS idx_scores_leaderboard 
+	ON scores(wpm DESC, accuracy DESC, created_at DESC) 
+	WHERE accuracy >= 85.0 AND duration = 60;
+	
+	CREATE INDEX IF NOT EXISTS idx_scores_user_rank 
+	ON scores(github_id, created_at DESC);
+	
+	CREATE INDEX IF NOT EXISTS idx_users_github_id 
+	ON users(github_id);
+
+	-- Function to update user updated_at timestamp
+	CREATE OR REPLACE FUNCTION update_user_updated_at()
+	RETURNS TRIGGER AS $$
+	BEGIN
+		NEW.updated_at = CURRENT_TIMESTAMP;
+		RETURN NEW;
+	END;
+	$$ LANGUAGE plpgsql;
+
+	-- Trigger for updated_at
+	DROP TRIGGER IF EXISTS update_user_updated_at_trigger ON users;
+	CREATE TRIGGER update_user_updated_at_trigger
+		BEFORE UPDATE ON users
+		FOR EACH ROW
+		EXECUTE FUNCTION update_user_updated_at();
+	`,
+	},
+	{
+		version: 2,
+		name:    "users_public",
+		// Private users keep submitting scores but are hidden from the public board
+		sql: `ALTER TABLE users ADD COLUMN IF NOT EXISTS public BOOLEAN NOT NULL DEFAULT TRUE;`,
+	},
+	{
+		version: 3,
+		name:    "scores_mode",
+		// Daily challenge scores are kept off the standard board and grouped by date
+		sql: `
+	ALTER TABLE scores ADD COLUMN IF NOT EXISTS mode VARCHAR(20) NOT NULL DEFAULT 'standard';
+	ALTER TABLE scores ADD COLUMN IF NOT EXISTS challenge_date DATE;
+
+	CREATE INDEX IF NOT EXISTS idx_scores_daily
+	ON scores(challenge_date, wpm DESC)
+	WHERE mode = 'daily';
+	`,
+	},
+	{
+		version: 4,
+		name:    "scores_seed",
+		// Word generator seed so a shared score can be replayed; NULL when unknown
+		sql: `ALTER TABLE scores ADD COLUMN IF NOT EXISTS seed BIGINT;`,
+	},
+	{
+		version: 5,
+		name:    "scores_suspect",
+		// Scores whose keystroke timing looked automated, kept for review
+		sql: `ALTER TABLE scores ADD COLUMN IF NOT EXISTS suspect BOOLEAN NOT NULL DEFAULT FALSE;`,
+	},
+	{
+		version: 6,
+		name:    "users_name_customized",
+		// Display names the user chose themselves, which signing in must not overwrite
+		sql: `ALTER TABLE users ADD COLUMN IF NOT EXISTS name_customized BOOLEAN NOT NULL DEFAULT FALSE;`,
+	},
+	{
+		version: 7,
+		name:    "scores_seed_generator",
+		// Generator version and word distribution a seed was played with, so it
+		// can still be replayed after the word list or sampling changes
+		sql: `
+		ALTER TABLE scores ADD COLUMN IF NOT EXISTS generator_version INTEGER;
+		ALTER TABLE scores ADD COLUMN IF NOT EXISTS word_dist VARCHAR(20);
+		`,
+	},
+	{
+		version: 8,
+		name:    "scores_idempotency_key",
+		// Client-chosen key so a score sent again (e.g. from the offline queue
+		// after a lost response) is stored only once per user
+		sql: `
+		ALTER TABLE scores ADD COLUMN IF NOT EXISTS idempotency_key VARCHAR(64);
+
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_scores_idempotency_key
+		ON scores(github_id, idempotency_key)
+		WHERE idempotency_key IS NOT NULL;
+		`,
+	},
+}
+
+// runMigrations applies every migration newer than the recorded schema version.
+// Each migration runs in its own transaction together with its version record.
+func runMigrations(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	var current int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+
+		if err := applyMigration(db, m); err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %w", m.version, m.name, err)
+		}
+		log.Printf("✅ Applied migration %d: %s", m.version, m.name)
+	}
+
+	return nil
+}
+
+// applyMigration runs a single migration and records it atomically
+func applyMigration(db *sql.DB, m migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(m.sql); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.version, m.name); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
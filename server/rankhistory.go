@@ -0,0 +1,125 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	RankHistoryWeeks    = 26        // Weeks of history returned, ending with the current one
+	RankHistoryCacheTTL = time.Hour // How long a user's computed history is served
+)
+
+// RankHistoryPoint is a user's standing at the end of one week
+type RankHistoryPoint struct {
+	WeekStart time.Time `json:"week_start"`
+	BestWPM   float64   `json:"best_wpm"` // Best qualifying WPM set before the week ended
+	Rank      int       `json:"rank"`
+}
+
+// rankHistoryWeeksQuery lists the start of every week since the user's first
+// qualifying score, at most RankHistoryWeeks of them, and nothing if they have
+// none. Each week is then ranked with rankedQuery, cut off at the week's end,
+// so past ranks are worked out exactly as the board would have shown them.
+//
+// Parameters: $1 MinAccuracy, $2 TargetDuration, $3 language, $4 github_id, $5 weeks.
+const rankHistoryWeeksQuery = `
+	SELECT generate_series(
+		GREATEST(date_trunc('week', earliest.created_at), date_trunc('week', NOW()) - ($5 - 1) * INTERVAL '1 week'),
+		date_trunc('week', NOW()),
+		INTERVAL '1 week')
+	FROM (
+		SELECT MIN(created_at) AS created_at
+		FROM scores
+		WHERE github_id = $4 AND accuracy >= $1 AND duration = $2 AND mode = 'standard' AND language = $3
+			AND NOT suspect
+	) earliest
+	WHERE earliest.created_at IS NOT NULL`
+
+// getRankHistory returns how the user's rank changed week by week, ranked among
+// public users like the board. Each point ranks the whole board again, so
+// results are cached for RankHistoryCacheTTL.
+func (s *APIServer) getRankHistory(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("Authorization")
+	if token == "" {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	token = strings.TrimPrefix(token, "Bearer ")
+
+	var githubID int
+	if err := s.db.QueryRow(`SELECT github_id FROM users WHERE access_token = $1`, token).Scan(&githubID); err != nil {
+		http.Error(w, "Invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	language := r.URL.Query().Get("language")
+	if language == "" {
+		language = "english"
+	}
+
+	cacheKey := fmt.Sprintf("%d/%s", githubID, language)
+	if cached, ok := s.rankHistoryCache.get(cacheKey); ok {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(cached)
+		return
+	}
+
+	weeks, err := s.rankHistoryWeeks(language, githubID)
+	if err != nil {
+		log.Printf("Error getting rank history weeks: %v", err)
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+
+	points := []RankHistoryPoint{}
+	for _, weekStart := range weeks {
+		weekEnd := weekStart.AddDate(0, 0, 7)
+		best, err := s.userRankEntry(rankFilter{
+			Mode:       ModeStandard,
+			Language:   language,
+			PublicOnly: true,
+			Viewer:     githubID,
+			Before:     &weekEnd,
+		}, githubID)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			log.Printf("Error ranking week of %s: %v", weekStart.Format("2006-01-02"), err)
+			http.Error(w, "Database error", http.StatusInternalServerError)
+			return
+		}
+		points = append(points, RankHistoryPoint{WeekStart: weekStart, BestWPM: best.WPM, Rank: best.Rank})
+	}
+
+	s.rankHistoryCache.set(cacheKey, points)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(points)
+}
+
+// rankHistoryWeeks returns the start of each week the user's rank history covers
+func (s *APIServer) rankHistoryWeeks(language string, githubID int) ([]time.Time, error) {
+	rows, err := s.db.Query(rankHistoryWeeksQuery, MinAccuracy, TargetDuration, language, githubID, RankHistoryWeeks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var weeks []time.Time
+	for rows.Next() {
+		var weekStart time.Time
+		if err := rows.Scan(&weekStart); err != nil {
+			return nil, fmt.Errorf("scanning rank history week: %w", err)
+		}
+		weeks = append(weeks, weekStart)
+	}
+	return weeks, rows.Err()
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRankHistory(t *testing.T) {
+	s := testServer(t)
+	language := "t" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	githubID := 2300000000 + int(time.Now().UnixNano()%1000000)
+	token := testUser(t, s, githubID)
+
+	// No qualifying scores, no history
+	var points []RankHistoryPoint
+	serve(t, s.getRankHistory, httptest.NewRequest("GET", "/api/user/rank-history?language="+language, nil), token, &points)
+	if len(points) != 0 {
+		t.Fatalf("%d weeks of history with no scores, want none", len(points))
+	}
+
+	// A score set two weeks ago starts the history at its week
+	playedAt := time.Now().AddDate(0, 0, -14).UTC()
+	body, _ := json.Marshal(LeaderboardEntry{WPM: 70, Accuracy: 95, Duration: TargetDuration, Language: language, PlayedAt: &playedAt})
+	var submitted LeaderboardEntry
+	serve(t, s.submitScore, httptest.NewRequest("POST", "/api/scores", bytes.NewReader(body)), token, &submitted)
+
+	s.rankHistoryCache = newResponseCache(RankHistoryCacheTTL)
+	serve(t, s.getRankHistory, httptest.NewRequest("GET", "/api/user/rank-history?language="+language, nil), token, &points)
+	if len(points) != 3 {
+		t.Fatalf("%d weeks of history, want 3: %+v", len(points), points)
+	}
+	for _, point := range points {
+		if point.Rank != 1 || point.BestWPM != 70 {
+			t.Errorf("week of %s: rank %d at %.0f WPM, want rank 1 at 70", point.WeekStart.Format("2006-01-02"), point.Rank, point.BestWPM)
+		}
+	}
+}
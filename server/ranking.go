@@ -0,0 +1,96 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// rankFilter selects the board a ranking is computed for
+type rankFilter struct {
+	Mode          string      // ModeStandard or ModeDaily
+	Language      string      // Empty ranks every language
+	ChallengeDate interface{} // Date for daily boards; nil for standard
+	PublicOnly    bool        // Leave out private users
+	Viewer        int         // github_id ranked even if private; 0 for none
+	Before        *time.Time  // Only count scores set before this time; nil for all
+}
+
+// args returns the parameters $1-$8 used by rankedQuery
+func (f rankFilter) args(extra ...interface{}) []interface{} {
+	args := []interface{}{MinAccuracy, TargetDuration, f.Mode, f.Language, f.ChallengeDate, f.PublicOnly, f.Viewer, f.Before}
+	return append(args, extra...)
+}
+
+// rankedQuery is the one definition of a leaderboard shared by every handler
+// that shows a rank. Each user is represented by their best qualifying score
+// and users are ranked by WPM, then accuracy, then whoever set it first. The
+// same order picks the best score, so a user's rank always matches the board.
+// Scores flagged as suspect never count.
+//
+// Names come from the users table rather than the copy stored with each score,
+// so a renamed user appears under their current name everywhere.
+//
+// It defines a "ranked" CTE (id, username, github_id, github_login, wpm,
+// accuracy, language, created_at, rank) and appends tail, which selects from
+// it. Parameters $1-$8 come from rankFilter.args; tail's own start at $9.
+func rankedQuery(tail string) string {
+	return `
+		WITH best AS (
+			SELECT DISTINCT ON (s.github_id)
+				s.id, u.username, s.github_id, u.github_login, s.wpm, s.accuracy, s.language, s.created_at
+			FROM scores s
+			JOIN users u ON u.github_id = s.github_id
+			WHERE s.accuracy >= $1 AND s.duration = $2 AND s.mode = $3
+				AND ($4 = '' OR s.language = $4)
+				AND s.challenge_date IS NOT DISTINCT FROM $5::date
+				AND (NOT $6::boolean OR u.public OR s.github_id = $7)
+				AND ($8::timestamptz IS NULL OR s.created_at < $8)
+				AND NOT s.suspect
+			ORDER BY s.github_id, s.wpm DESC, s.accuracy DESC, s.created_at ASC, s.id ASC
+		),
+		ranked AS (
+			SELECT best.*,
+				ROW_NUMBER() OVER (ORDER BY wpm DESC, accuracy DESC, created_at ASC, id ASC) as rank
+			FROM best
+		)
+		` + tail
+}
+
+// queryRanked returns the ranked board rows selected by tail
+func (s *APIServer) queryRanked(filter rankFilter, tail string, extra ...interface{}) ([]LeaderboardEntry, error) {
+	rows, err := s.db.Query(rankedQuery(`
+		SELECT id, username, github_id, github_login, wpm, accuracy, language, created_at, rank
+		FROM ranked
+		`+tail), filter.args(extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []LeaderboardEntry{}
+	for rows.Next() {
+		var entry LeaderboardEntry
+		if err := rows.Scan(&entry.ID, &entry.Username, &entry.GitHubID, &entry.GitHubLogin, &entry.WPM,
+			&entry.Accuracy, &entry.Language, &entry.CreatedAt, &entry.Rank); err != nil {
+			return nil, fmt.Errorf("scanning ranked row: %w", err)
+		}
+		entry.Duration = TargetDuration
+		entry.Mode = filter.Mode
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// userRankEntry returns a user's best score and rank on the board, with
+// sql.ErrNoRows if they have no qualifying score on it
+func (s *APIServer) userRankEntry(filter rankFilter, githubID int) (LeaderboardEntry, error) {
+	entries, err := s.queryRanked(filter, `WHERE github_id = $9`, githubID)
+	if err != nil {
+		return LeaderboardEntry{}, err
+	}
+	if len(entries) == 0 {
+		return LeaderboardEntry{}, sql.ErrNoRows
+	}
+	return entries[0], nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// testServer connects to the database named by ZENTYPE_TEST_DATABASE_URL and
+// migrates it, skipping the test when the variable is unset
+func testServer(t *testing.T) *APIServer {
+	t.Helper()
+	dbURL := os.Getenv("ZENTYPE_TEST_DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("ZENTYPE_TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sql.Open("postgres", dbURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := initDB(db); err != nil {
+		t.Fatal(err)
+	}
+
+	return &APIServer{
+		db:                db,
+		distributionCache: newResponseCache(DistributionCacheTTL),
+		languageCache:     newResponseCache(DistributionCacheTTL),
+		rankHistoryCache:  newResponseCache(RankHistoryCacheTTL),
+		antiCheatMode:     AntiCheatOff,
+	}
+}
+
+// testUser creates a user with its own access token and removes it, with its
+// scores, when the test ends
+func testUser(t *testing.T, s *APIServer, githubID int) string {
+	t.Helper()
+	token := fmt.Sprintf("test-token-%d", githubID)
+	login := fmt.Sprintf("test%d", githubID)
+	_, err := s.db.Exec(`
+		INSERT INTO users (username, github_id, github_login, access_token)
+		VALUES ($1, $2, $1, $3)`,
+		login, githubID, token,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		s.db.Exec(`DELETE FROM scores WHERE github_id = $1`, githubID)
+		s.db.Exec(`DELETE FROM users WHERE github_id = $1`, githubID)
+	})
+	return token
+}
+
+// serve runs one request through handler and decodes its JSON response into out
+func serve(t *testing.T, handler http.HandlerFunc, req *http.Request, token string, out interface{}) {
+	t.Helper()
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusOK && rec.Code != http.StatusCreated {
+		t.Fatalf("%s %s: %d %s", req.Method, req.URL, rec.Code, rec.Body.String())
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSubmittedRankMatchesBoard(t *testing.T) {
+	s := testServer(t)
+
+	// A language of its own keeps the board free of other data
+	language := "t" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	baseID := 2000000000 + int(time.Now().UnixNano()%1000000)*10
+
+	// Ties on WPM are broken by accuracy, then by whoever set the score first
+	submissions := []struct {
+		user     int
+		wpm      float64
+		accuracy float64
+	}{
+		{user: 0, wpm: 80, accuracy: 95},
+		{user: 1, wpm: 80, accuracy: 97},
+		{user: 2, wpm: 80, accuracy: 97},
+		{user: 3, wpm: 90, accuracy: 86},
+		{user: 0, wpm: 80, accuracy: 99},  // Improves user 0 past users 1 and 2
+		{user: 2, wpm: 70, accuracy: 100}, // Not a best, so user 2 keeps their rank
+	}
+
+	tokens := make(map[int]string)
+	for _, sub := range submissions {
+		if _, ok := tokens[sub.user]; !ok {
+			tokens[sub.user] = testUser(t, s, baseID+sub.user)
+		}
+	}
+
+	for i, sub := range submissions {
+		body, _ := json.Marshal(LeaderboardEntry{WPM: sub.wpm, Accuracy: sub.accuracy, Duration: TargetDuration, Language: language})
+		var submitted LeaderboardEntry
+		serve(t, s.submitScore, httptest.NewRequest("POST", "/api/scores", bytes.NewReader(body)), tokens[sub.user], &submitted)
+
+		var board struct {
+			Entries []LeaderboardEntry `json:"entries"`
+		}
+		serve(t, s.getLeaderboard, httptest.NewRequest("GET", "/api/leaderboard?limit=50&language="+language, nil), tokens[sub.user], &board)
+		boardRank := 0
+		for _, entry := range board.Entries {
+			if entry.GitHubID == baseID+sub.user {
+				boardRank = entry.Rank
+			}
+		}
+
+		var stats UserStats
+		serve(t, s.getUserRank, httptest.NewRequest("GET", "/api/user/rank?language="+language, nil), tokens[sub.user], &stats)
+
+		if submitted.Rank != boardRank || stats.Rank != boardRank {
+			t.Errorf("submission %d: rank %d after submitting, %d from /user/rank, %d on the board",
+				i, submitted.Rank, stats.Rank, boardRank)
+		}
+	}
+
+	// The final board, in order
+	var board struct {
+		Entries []LeaderboardEntry `json:"entries"`
+	}
+	serve(t, s.getLeaderboard, httptest.NewRequest("GET", "/api/leaderboard?language="+language, nil), tokens[0], &board)
+	want := []int{3, 0, 1, 2}
+	if len(board.Entries) != len(want) {
+		t.Fatalf("board has %d entries, want %d", len(board.Entries), len(want))
+	}
+	for i, user := range want {
+		if got := board.Entries[i].GitHubID; got != baseID+user {
+			t.Errorf("rank %d is github_id %d, want %d", i+1, got, baseID+user)
+		}
+	}
+}
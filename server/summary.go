@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// UserSummary compares the user's qualifying scores this week with last week
+type UserSummary struct {
+	QualifiedThisWeek int     `json:"qualified_this_week"`
+	QualifiedLastWeek int     `json:"qualified_last_week"`
+	BestWPMThisWeek   float64 `json:"best_wpm_this_week"`
+	BestWPMAllTime    float64 `json:"best_wpm_all_time"`
+}
+
+// getUserSummary returns how many qualifying scores the user set this week
+// and last week, and their best WPM this week against their best ever. Weeks
+// start on Monday, in the database's time zone.
+func (s *APIServer) getUserSummary(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("Authorization")
+	if token == "" {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	token = strings.TrimPrefix(token, "Bearer ")
+
+	var githubID int
+	if err := s.db.QueryRow(`SELECT github_id FROM users WHERE access_token = $1`, token).Scan(&githubID); err != nil {
+		http.Error(w, "Invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	language := r.URL.Query().Get("language")
+	if language == "" {
+		language = "english"
+	}
+
+	var summary UserSummary
+	err := s.db.QueryRow(`
+		SELECT
+			COUNT(*) FILTER (WHERE created_at >= date_trunc('week', NOW())),
+			COUNT(*) FILTER (WHERE created_at >= date_trunc('week', NOW()) - INTERVAL '1 week'
+				AND created_at < date_trunc('week', NOW())),
+			COALESCE(MAX(wpm) FILTER (WHERE created_at >= date_trunc('week', NOW())), 0),
+			COALESCE(MAX(wpm), 0)
+		FROM scores
+		WHERE github_id = $1 AND accuracy >= $2 AND duration = $3 AND mode = $4 AND language = $5 AND NOT suspect`,
+		githubID, MinAccuracy, TargetDuration, ModeStandard, language,
+	).Scan(&summary.QualifiedThisWeek, &summary.QualifiedLastWeek, &summary.BestWPMThisWeek, &summary.BestWPMAllTime)
+	if err != nil {
+		log.Printf("Error getting user summary: %v", err)
+		http.Error(w, "Database error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summary)
+}